@@ -0,0 +1,89 @@
+package otelTracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// baggageKeys are the baggage members promoted to span attributes and log fields.
+var baggageKeys []string
+
+// SetBaggage returns a copy of ctx with the baggage member key set to value.
+func SetBaggage(ctx context.Context, key, value string) (context.Context, error) {
+	member, err := baggage.NewMemberRaw(key, value)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to create baggage member: %w", err)
+	}
+	b, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to set baggage member: %w", err)
+	}
+	return baggage.ContextWithBaggage(ctx, b), nil
+}
+
+// GetBaggage returns the value of the baggage member key in ctx, or an empty string.
+func GetBaggage(ctx context.Context, key string) string {
+	return baggage.FromContext(ctx).Member(key).Value()
+}
+
+// parseBaggageKeys parses a comma separated list of baggage member keys.
+func parseBaggageKeys(raw string) []string {
+	var keys []string
+	for _, key := range strings.Split(raw, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// baggageFields returns the promoted baggage members of ctx as log fields.
+func baggageFields(ctx context.Context) logrus.Fields {
+	if len(baggageKeys) == 0 {
+		return nil
+	}
+	b := baggage.FromContext(ctx)
+	if b.Len() == 0 {
+		return nil
+	}
+	fields := logrus.Fields{}
+	for _, key := range baggageKeys {
+		if member := b.Member(key); member.Key() != "" {
+			fields[key] = member.Value()
+		}
+	}
+	return fields
+}
+
+// baggageSpanProcessor copies the promoted baggage members of the parent context onto new spans.
+type baggageSpanProcessor struct {
+	keys []string
+}
+
+func (p *baggageSpanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	b := baggage.FromContext(parent)
+	if b.Len() == 0 {
+		return
+	}
+	for _, key := range p.keys {
+		if member := b.Member(key); member.Key() != "" {
+			s.SetAttributes(attribute.String(key, member.Value()))
+		}
+	}
+}
+
+func (p *baggageSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {}
+
+func (p *baggageSpanProcessor) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (p *baggageSpanProcessor) ForceFlush(ctx context.Context) error {
+	return nil
+}
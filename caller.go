@@ -0,0 +1,154 @@
+package otelTracing
+
+import (
+	"context"
+	"path"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// callerInfo describes a call site, it is resolved once per program counter.
+type callerInfo struct {
+	function  string
+	namespace string
+	file      string
+	shortFile string
+	line      int
+	spanName  string
+	attrs     []attribute.KeyValue
+	fields    logrus.Fields
+}
+
+var callerCache sync.Map
+
+// mainModulePath is the module path of the running program and mainPackagePath the import
+// path of its main package, used to trim caller file paths.
+var mainModulePath, mainPackagePath = func() (string, string) {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Path, info.Path
+	}
+	return "", ""
+}()
+
+// TraceStartAuto starts a new span named after the calling package and function, with
+// the code.function, code.namespace, code.filepath and code.lineno attributes.
+// The span must be ended by calling End.
+func TraceStartAuto(ctx context.Context, opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+	//nolint: spancheck
+	return traceStartAuto(ctx, 1, opts)
+}
+
+func traceStartAuto(ctx context.Context, skip int, opts []oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+	info := resolveCaller(skip + 1)
+	opts = append([]oteltrace.SpanStartOption{oteltrace.WithAttributes(info.attrs...)}, opts...)
+	//nolint: spancheck
+	return Tracer.Start(ctx, info.spanName, opts...)
+}
+
+// resolveCaller returns the call site skip frames above the caller of resolveCaller.
+func resolveCaller(skip int) *callerInfo {
+	pc := callerPC(skip + 1)
+	if pc == 0 {
+		return &callerInfo{spanName: "unknown"}
+	}
+	return callerFromPC(pc)
+}
+
+// callerPC returns the program counter of the call site skip frames above the caller of callerPC.
+func callerPC(skip int) uintptr {
+	var pcs [1]uintptr
+	if runtime.Callers(skip+2, pcs[:]) == 0 {
+		return 0
+	}
+	return pcs[0]
+}
+
+// funcPC returns a program counter inside the function fn, to report fn as the call site.
+func funcPC(fn interface{}) uintptr {
+	// call sites are return addresses resolved one byte back, step past the function entry
+	return reflect.ValueOf(fn).Pointer() + 1
+}
+
+// callerFromPC returns the call site of a program counter as returned by runtime.Callers.
+func callerFromPC(pc uintptr) *callerInfo {
+	if v, ok := callerCache.Load(pc); ok {
+		return v.(*callerInfo)
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	namespace, function := splitFunctionName(frame.Function)
+	shortFile := trimFilePath(frame.File, namespace)
+	spanName := function
+	if namespace != "" {
+		spanName = namespace[strings.LastIndex(namespace, "/")+1:] + "." + function
+	}
+	info := &callerInfo{
+		function:  function,
+		namespace: namespace,
+		file:      frame.File,
+		shortFile: shortFile,
+		line:      frame.Line,
+		spanName:  spanName,
+		attrs: []attribute.KeyValue{
+			semconv.CodeFunction(function),
+			semconv.CodeNamespace(namespace),
+			semconv.CodeFilepath(frame.File),
+			semconv.CodeLineNumber(frame.Line),
+		},
+		fields: logrus.Fields{
+			string(semconv.CodeFunctionKey):   function,
+			string(semconv.CodeNamespaceKey):  namespace,
+			string(semconv.CodeFilepathKey):   shortFile,
+			string(semconv.CodeLineNumberKey): frame.Line,
+		},
+	}
+	v, _ := callerCache.LoadOrStore(pc, info)
+	return v.(*callerInfo)
+}
+
+// splitFunctionName splits "github.com/a/b/pkg.(*T).Method" into the package path
+// "github.com/a/b/pkg" and the function "(*T).Method".
+func splitFunctionName(name string) (string, string) {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	dot += slash + 1
+	return name[:dot], name[dot+1:]
+}
+
+// trimFilePath returns the file path relative to the configured prefix or, by default,
+// to the root of the main module when the file belongs to the package namespace of it.
+func trimFilePath(file, namespace string) string {
+	if prefix := tracingConfig.LogCallerTrimPrefix; prefix != "" {
+		return strings.TrimPrefix(strings.TrimPrefix(file, prefix), "/")
+	}
+	// functions of the main package report "main" instead of their import path
+	if namespace == "main" {
+		namespace = mainPackagePath
+	}
+	if mainModulePath == "" || !strings.HasPrefix(namespace, mainModulePath) {
+		return file
+	}
+
+	// the package directory relative to the module root, e.g. "/internal/handler"
+	rel := strings.TrimPrefix(namespace, mainModulePath)
+	if rel != "" && !strings.HasPrefix(rel, "/") {
+		return file
+	}
+	dir := path.Dir(file)
+	if !strings.HasSuffix(dir, rel) {
+		return file
+	}
+	root := strings.TrimSuffix(dir, rel)
+	return strings.TrimPrefix(file, root+"/")
+}
@@ -0,0 +1,95 @@
+package otelTracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Inject injects the span context and baggage of ctx into carrier with the configured propagators.
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+}
+
+// Extract returns a copy of ctx with the span context and baggage extracted from carrier
+// with the configured propagators.
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// MapCarrier adapts a map[string]string, such as a job payload, to a propagation.TextMapCarrier.
+type MapCarrier = propagation.MapCarrier
+
+// ByteHeader is a message header with a []byte value, such as a Kafka record header.
+type ByteHeader struct {
+	Key   string
+	Value []byte
+}
+
+// ByteHeadersCarrier adapts a slice of ByteHeader to a propagation.TextMapCarrier,
+// it must be used through a pointer so Set can append to the slice.
+type ByteHeadersCarrier []ByteHeader
+
+// Get returns the value of the first header with the given key.
+func (c *ByteHeadersCarrier) Get(key string) string {
+	for _, h := range *c {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// Set replaces the value of the header with the given key, or appends a new header.
+func (c *ByteHeadersCarrier) Set(key, value string) {
+	for i, h := range *c {
+		if h.Key == key {
+			(*c)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c = append(*c, ByteHeader{Key: key, Value: []byte(value)})
+}
+
+// Keys returns the keys of all headers.
+func (c *ByteHeadersCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c))
+	for _, h := range *c {
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
+
+// KeyValueStore is implemented by message attributes that are read and written by key,
+// such as SQS message attributes.
+type KeyValueStore interface {
+	Lookup(key string) (string, bool)
+	Store(key, value string)
+	Range(fn func(key, value string))
+}
+
+// KeyValueCarrier adapts a KeyValueStore to a propagation.TextMapCarrier.
+type KeyValueCarrier struct {
+	Store KeyValueStore
+}
+
+// Get returns the value stored for key.
+func (c KeyValueCarrier) Get(key string) string {
+	value, _ := c.Store.Lookup(key)
+	return value
+}
+
+// Set stores value for key.
+func (c KeyValueCarrier) Set(key, value string) {
+	c.Store.Store(key, value)
+}
+
+// Keys returns the keys of the store.
+func (c KeyValueCarrier) Keys() []string {
+	var keys []string
+	c.Store.Range(func(key, _ string) {
+		keys = append(keys, key)
+	})
+	return keys
+}
@@ -9,10 +9,60 @@ import (
 // Config holds the configuration for the telemetry.
 type Config struct {
 	// App configuration
-	OtlpEndpoint   string `env:"OTEL_TRACING_OTLP_ENDPOINT" default:""`
-	ServiceName    string `env:"OTEL_TRACING_SERVICE_NAME" default:"service"`
-	ServiceVersion string `env:"OTEL_TRACING_SERVICE_VERSION" default:"1.0.0"`
-	Insecure       bool   `env:"OTEL_TRACING_INSECURE_MODE" default:"true"`
+	OtlpEndpoint      string `env:"OTEL_TRACING_OTLP_ENDPOINT" default:""`
+	ServiceName       string `env:"OTEL_TRACING_SERVICE_NAME" default:"service"`
+	ServiceVersion    string `env:"OTEL_TRACING_SERVICE_VERSION" default:"1.0.0"`
+	Insecure          bool   `env:"OTEL_TRACING_INSECURE_MODE" default:"true"`
+	Propagators       string `env:"OTEL_TRACING_PROPAGATORS" default:""`
+	ShutdownTimeoutMs int    `env:"OTEL_TRACING_SHUTDOWN_TIMEOUT_MS" default:"5000"`
+
+	// Log configuration
+	LogLevel              string `env:"OTEL_TRACING_LOG_LEVEL" default:"info"`
+	LogLevelOverrides     string `env:"OTEL_TRACING_LOG_LEVEL_OVERRIDES" default:""`
+	LogLevelOverridesFile string `env:"OTEL_TRACING_LOG_LEVEL_OVERRIDES_FILE" default:""`
+	LogConsole            string `env:"OTEL_TRACING_LOG_CONSOLE" default:""`
+	LogConsoleFormat      string `env:"OTEL_TRACING_LOG_CONSOLE_FORMAT" default:"text"`
+	LogCaller             bool   `env:"OTEL_TRACING_LOG_CALLER" default:"true"`
+	LogCallerTrimPrefix   string `env:"OTEL_TRACING_LOG_CALLER_TRIM_PREFIX" default:""`
+	LogSpanEvents         bool   `env:"OTEL_TRACING_LOG_SPAN_EVENTS" default:"false"`
+	LogSpanEventsLevel    string `env:"OTEL_TRACING_LOG_SPAN_EVENTS_LEVEL" default:"info"`
+	LogErrorSpanStatus    bool   `env:"OTEL_TRACING_LOG_ERROR_SPAN_STATUS" default:"false"`
+
+	// Log sampling configuration
+	LogSamplingEnabled           bool   `env:"OTEL_TRACING_LOG_SAMPLING_ENABLED" default:"false"`
+	LogSamplingTickMs            int    `env:"OTEL_TRACING_LOG_SAMPLING_TICK_MS" default:"1000"`
+	LogSamplingFirst             int    `env:"OTEL_TRACING_LOG_SAMPLING_FIRST" default:"100"`
+	LogSamplingThereafter        int    `env:"OTEL_TRACING_LOG_SAMPLING_THEREAFTER" default:"100"`
+	LogSamplingKeepLevel         string `env:"OTEL_TRACING_LOG_SAMPLING_KEEP_LEVEL" default:"error"`
+	LogSamplingKeepSampledTraces bool   `env:"OTEL_TRACING_LOG_SAMPLING_KEEP_SAMPLED_TRACES" default:"false"`
+
+	// Span limits configuration
+	AttributeCountLimit       int `env:"OTEL_TRACING_ATTRIBUTE_COUNT_LIMIT" default:"0"`
+	AttributeValueLengthLimit int `env:"OTEL_TRACING_ATTRIBUTE_VALUE_LENGTH_LIMIT" default:"0"`
+	SpanEventCountLimit       int `env:"OTEL_TRACING_SPAN_EVENT_COUNT_LIMIT" default:"0"`
+	SpanLinkCountLimit        int `env:"OTEL_TRACING_SPAN_LINK_COUNT_LIMIT" default:"0"`
+
+	// Goroutine configuration
+	GoroutineNewRoot bool `env:"OTEL_TRACING_GOROUTINE_NEW_ROOT" default:"false"`
+
+	// Baggage configuration
+	BaggagePromoteKeys string `env:"OTEL_TRACING_BAGGAGE_PROMOTE_KEYS" default:""`
+
+	// Metrics configuration
+	ExemplarFilter string `env:"OTEL_TRACING_EXEMPLAR_FILTER" default:""`
+
+	// Span metrics configuration
+	SpanMetricsEnabled bool `env:"OTEL_TRACING_SPAN_METRICS_ENABLED" default:"false"`
+
+	// Tail sampling configuration
+	TailSamplingEnabled            bool    `env:"OTEL_TRACING_TAIL_SAMPLING_ENABLED" default:"false"`
+	TailSamplingDecisionWaitMs     int     `env:"OTEL_TRACING_TAIL_SAMPLING_DECISION_WAIT_MS" default:"10000"`
+	TailSamplingCompletionWaitMs   int     `env:"OTEL_TRACING_TAIL_SAMPLING_COMPLETION_WAIT_MS" default:"1000"`
+	TailSamplingLatencyThresholdMs int     `env:"OTEL_TRACING_TAIL_SAMPLING_LATENCY_THRESHOLD_MS" default:"1000"`
+	TailSamplingBaseRate           float64 `env:"OTEL_TRACING_TAIL_SAMPLING_BASE_RATE" default:"0.1"`
+	TailSamplingAttributes         string  `env:"OTEL_TRACING_TAIL_SAMPLING_ATTRIBUTES" default:""`
+	TailSamplingMaxTraces          int     `env:"OTEL_TRACING_TAIL_SAMPLING_MAX_TRACES" default:"10000"`
+	TailSamplingMaxSpansPerTrace   int     `env:"OTEL_TRACING_TAIL_SAMPLING_MAX_SPANS_PER_TRACE" default:"1000"`
 }
 
 // NewConfigFromEnv creates a new telemetry config from the environment.
@@ -0,0 +1,107 @@
+package otelTracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// Go runs fn in a new goroutine inside a span with the given name. The span is a child of
+// the span in ctx, or a new root linked to it when OTEL_TRACING_GOROUTINE_NEW_ROOT is set.
+// An error or a panic of fn is recorded on the span and logged.
+func Go(ctx context.Context, name string, fn func(ctx context.Context) error) {
+	ctx, span := startGoroutineSpan(ctx, name)
+	go func() {
+		defer span.End()
+		_ = runGoroutine(ctx, span, name, fn, true)
+	}()
+}
+
+// Group is a collection of traced goroutines working on subtasks of the same task.
+type Group struct {
+	ctx    context.Context
+	span   oteltrace.Span
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	errs   []error
+	wait   sync.Once
+	err    error
+}
+
+// NewGroup starts a span with the given name for a new Group. The returned context is
+// canceled the first time a goroutine of the group fails or when Wait returns.
+func NewGroup(ctx context.Context, name string) (*Group, context.Context) {
+	ctx, span := Tracer.Start(ctx, name)
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{ctx: ctx, span: span, cancel: cancel}, ctx
+}
+
+// Go runs fn in a new goroutine inside a span with the given name, see Go.
+func (g *Group) Go(name string, fn func(ctx context.Context) error) {
+	ctx, span := startGoroutineSpan(g.ctx, name)
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer span.End()
+		if err := runGoroutine(ctx, span, name, fn, false); err != nil {
+			g.mu.Lock()
+			g.errs = append(g.errs, err)
+			g.mu.Unlock()
+			g.cancel()
+		}
+	}()
+}
+
+// Wait blocks until all goroutines of the group have returned, ends the group span and
+// returns the errors of all failed goroutines joined together. Later calls return the same error.
+func (g *Group) Wait() error {
+	g.wait.Do(func() {
+		g.wg.Wait()
+		g.cancel()
+
+		g.mu.Lock()
+		g.err = errors.Join(g.errs...)
+		g.mu.Unlock()
+
+		recordSpanError(g.span, g.err)
+		g.span.End()
+	})
+	return g.err
+}
+
+// startGoroutineSpan starts the span of a goroutine as configured.
+func startGoroutineSpan(ctx context.Context, name string) (context.Context, oteltrace.Span) {
+	if tracingConfig.GoroutineNewRoot {
+		//nolint: spancheck
+		return StartLinkedRoot(ctx, name, oteltrace.SpanContextFromContext(ctx))
+	}
+	//nolint: spancheck
+	return Tracer.Start(ctx, name)
+}
+
+// runGoroutine runs fn and records its error, logging it when logError is set.
+// A panic is recovered, logged and returned as an error. Both are logged with fn as caller.
+func runGoroutine(ctx context.Context, span oteltrace.Span, name string, fn func(ctx context.Context) error, logError bool) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			recordPanic(span, r)
+			err = fmt.Errorf("panic in %s: %v", name, r)
+			logMessagePC(ctx, funcPC(fn), logrus.ErrorLevel, "", []interface{}{err}, logrus.Fields{
+				"stacktrace": string(debug.Stack()),
+			})
+		}
+	}()
+
+	err = fn(ctx)
+	recordSpanError(span, err)
+	if err != nil && logError {
+		logMessagePC(ctx, funcPC(fn), logrus.ErrorLevel, "", []interface{}{err}, nil)
+	}
+	return err
+}
@@ -0,0 +1,65 @@
+package otelTracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RequestIDHeader is the request header whose value MiddlewareLogger uses as request ID,
+// a random one is generated when it is missing.
+const RequestIDHeader = "X-Request-Id"
+
+// logFieldsContextKey is the context key holding the accumulated log fields.
+type logFieldsContextKey struct{}
+
+// WithLogFields returns a copy of ctx holding fields in addition to the log fields already in
+// ctx, fields replacing the ones with the same keys. The LogX functions add them to every record.
+func WithLogFields(ctx context.Context, fields logrus.Fields) context.Context {
+	current := contextLogFields(ctx)
+	merged := make(logrus.Fields, len(current)+len(fields))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, logFieldsContextKey{}, merged)
+}
+
+// baseloger shares the hooks and the console output of loger at the base log level, loger being
+// at the most verbose level of the per-package overrides.
+var baseloger *logrus.Logger
+
+// newBaseLogger creates a logger writing like log, its level follows the base log level.
+func newBaseLogger(log *logrus.Logger) *logrus.Logger {
+	return &logrus.Logger{
+		Out:       log.Out,
+		Hooks:     log.Hooks,
+		Formatter: log.Formatter,
+		Level:     log.GetLevel(),
+		ExitFunc:  log.ExitFunc,
+	}
+}
+
+// LoggerFromContext returns a logrus entry for ctx with the log fields of ctx. Records logged
+// through it are enabled from the base log level, the log sampler and the per-package level
+// overrides only apply to the LogX functions.
+func LoggerFromContext(ctx context.Context) *logrus.Entry {
+	return baseloger.WithContext(ctx).WithFields(contextLogFields(ctx))
+}
+
+// contextLogFields returns the log fields of ctx, the map must not be modified.
+func contextLogFields(ctx context.Context) logrus.Fields {
+	fields, _ := ctx.Value(logFieldsContextKey{}).(logrus.Fields)
+	return fields
+}
+
+// newRequestID returns a random request ID.
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
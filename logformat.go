@@ -0,0 +1,237 @@
+package otelTracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// badKey is the field holding a value that is missing its key in a key/value list.
+const badKey = "!BADKEY"
+
+// LogTracef logs a message formatted with fmt.Sprintf at the trace level.
+func LogTracef(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, 1, logrus.TraceLevel, format, args)
+}
+
+// LogTraceKV logs msg with alternating key/value fields at the trace level.
+func LogTraceKV(ctx context.Context, msg string, keyvals ...interface{}) {
+	logKV(ctx, 1, logrus.TraceLevel, msg, keyvals)
+}
+
+// LogDebugf logs a message formatted with fmt.Sprintf at the debug level.
+func LogDebugf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, 1, logrus.DebugLevel, format, args)
+}
+
+// LogDebugKV logs msg with alternating key/value fields at the debug level.
+func LogDebugKV(ctx context.Context, msg string, keyvals ...interface{}) {
+	logKV(ctx, 1, logrus.DebugLevel, msg, keyvals)
+}
+
+// LogPrintf logs a message formatted with fmt.Sprintf at the info level.
+func LogPrintf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, 1, logrus.InfoLevel, format, args)
+}
+
+// LogPrintKV logs msg with alternating key/value fields at the info level.
+func LogPrintKV(ctx context.Context, msg string, keyvals ...interface{}) {
+	logKV(ctx, 1, logrus.InfoLevel, msg, keyvals)
+}
+
+// LogInfof logs a message formatted with fmt.Sprintf at the info level.
+func LogInfof(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, 1, logrus.InfoLevel, format, args)
+}
+
+// LogInfoKV logs msg with alternating key/value fields at the info level.
+func LogInfoKV(ctx context.Context, msg string, keyvals ...interface{}) {
+	logKV(ctx, 1, logrus.InfoLevel, msg, keyvals)
+}
+
+// LogWarnf logs a message formatted with fmt.Sprintf at the warning level.
+func LogWarnf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, 1, logrus.WarnLevel, format, args)
+}
+
+// LogWarnKV logs msg with alternating key/value fields at the warning level.
+func LogWarnKV(ctx context.Context, msg string, keyvals ...interface{}) {
+	logKV(ctx, 1, logrus.WarnLevel, msg, keyvals)
+}
+
+// LogErrorf logs a message formatted with fmt.Sprintf at the error level.
+func LogErrorf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, 1, logrus.ErrorLevel, format, args)
+}
+
+// LogErrorKV logs msg with alternating key/value fields at the error level.
+func LogErrorKV(ctx context.Context, msg string, keyvals ...interface{}) {
+	logKV(ctx, 1, logrus.ErrorLevel, msg, keyvals)
+}
+
+// LogFatalf logs a message formatted with fmt.Sprintf at the fatal level and exits the process.
+func LogFatalf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, 1, logrus.FatalLevel, format, args)
+}
+
+// LogFatalKV logs msg with alternating key/value fields at the fatal level and exits the process.
+func LogFatalKV(ctx context.Context, msg string, keyvals ...interface{}) {
+	logKV(ctx, 1, logrus.FatalLevel, msg, keyvals)
+}
+
+// LogPanicf logs a message formatted with fmt.Sprintf at the panic level and panics.
+func LogPanicf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, 1, logrus.PanicLevel, format, args)
+}
+
+// LogPanicKV logs msg with alternating key/value fields at the panic level and panics.
+func LogPanicKV(ctx context.Context, msg string, keyvals ...interface{}) {
+	logKV(ctx, 1, logrus.PanicLevel, msg, keyvals)
+}
+
+// logf logs a formatted message at the given level, skip is the number of frames above logf to report as caller.
+func logf(ctx context.Context, skip int, level logrus.Level, format string, args []interface{}) {
+	logMessage(ctx, skip+1, level, format, args, nil)
+}
+
+// logKV logs msg with key/value fields at the given level, skip is the number of frames above logKV to report as caller.
+func logKV(ctx context.Context, skip int, level logrus.Level, msg string, keyvals []interface{}) {
+	if level != logrus.FatalLevel && !loger.IsLevelEnabled(level) {
+		return
+	}
+	logMessage(ctx, skip+1, level, "", []interface{}{msg}, kvFields(keyvals))
+}
+
+// logMessage is the common path of the LogX functions, skip is the number of frames above
+// logMessage to report as caller.
+func logMessage(ctx context.Context, skip int, level logrus.Level, format string, args []interface{}, fields logrus.Fields) {
+	// the records disabled for every package do not need the call site
+	if level != logrus.FatalLevel && !loger.IsLevelEnabled(level) {
+		return
+	}
+	logMessagePC(ctx, callerPC(skip+1), level, format, args, fields)
+}
+
+// logMessagePC logs a record of the call site at pc. The message is formatted with format, or
+// with fmt.Sprint when format is empty. The log sampler keys the records on the format or,
+// since a rendered message differs with its arguments, on the call site.
+func logMessagePC(ctx context.Context, pc uintptr, level logrus.Level, format string, args []interface{}, fields logrus.Fields) {
+	if level == logrus.FatalLevel {
+		defer loger.Exit(1)
+	}
+	if !logLevelEnabledPC(level, pc) {
+		return
+	}
+
+	if logsampler != nil {
+		var key uint64
+		if format == "" {
+			key = callSiteKey(pc)
+		} else {
+			key = templateKey(format)
+		}
+		if !logsampler.allow(ctx, level, key) {
+			return
+		}
+	}
+
+	var msg string
+	if format == "" {
+		msg = fmt.Sprint(args...)
+	} else {
+		msg = fmt.Sprintf(format, args...)
+	}
+
+	entry := logEntry(ctx, pc)
+	if len(fields) > 0 {
+		entry = entry.WithFields(fields)
+	}
+	if tracingConfig.LogSpanEvents && level <= logSpanEventLevel {
+		addLogSpanEvent(ctx, level, msg, entry.Data)
+	}
+	if tracingConfig.LogErrorSpanStatus && level <= logrus.ErrorLevel {
+		markSpanErrored(ctx, msg, args, fields)
+	}
+	entry.Log(level, msg)
+}
+
+// markSpanErrored sets the status of the recording span in ctx to Error and records the
+// errors found among the log arguments and fields, with their type and stack trace.
+func markSpanErrored(ctx context.Context, msg string, args []interface{}, fields logrus.Fields) {
+	span := oteltrace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	span.SetStatus(codes.Error, msg)
+	for _, arg := range args {
+		if err, ok := arg.(error); ok {
+			span.RecordError(err, oteltrace.WithStackTrace(true))
+		}
+	}
+	for _, value := range fields {
+		if err, ok := value.(error); ok {
+			span.RecordError(err, oteltrace.WithStackTrace(true))
+		}
+	}
+}
+
+// addLogSpanEvent mirrors a log record as an event of the recording span in ctx.
+func addLogSpanEvent(ctx context.Context, level logrus.Level, msg string, fields logrus.Fields) {
+	span := oteltrace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(fields)+2)
+	attrs = append(attrs,
+		attribute.String("log.severity", level.String()),
+		attribute.String("log.message", msg),
+	)
+	for key, value := range fields {
+		attrs = append(attrs, fieldAttribute(key, value))
+	}
+	span.AddEvent("log", oteltrace.WithAttributes(attrs...))
+}
+
+// fieldAttribute converts a log field to an attribute keeping the basic value types.
+func fieldAttribute(key string, value interface{}) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	case error:
+		return attribute.String(key, v.Error())
+	default:
+		return attribute.String(key, fmt.Sprint(v))
+	}
+}
+
+// kvFields converts alternating keys and values into log fields keeping the value types,
+// so they are exported as typed log attributes.
+func kvFields(keyvals []interface{}) logrus.Fields {
+	fields := make(logrus.Fields, (len(keyvals)+1)/2)
+	for i := 0; i < len(keyvals); i += 2 {
+		if i+1 == len(keyvals) {
+			fields[badKey] = keyvals[i]
+			break
+		}
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
+		}
+		fields[key] = keyvals[i+1]
+	}
+	return fields
+}
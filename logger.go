@@ -0,0 +1,173 @@
+package otelTracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/faizal-asep-outlook/otel-tracing/config"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/bridges/otellogrus"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// logSpanEventLevel is the minimum level of the log records mirrored as span events.
+var logSpanEventLevel logrus.Level
+
+// newLogger creates the logrus logger exporting to the logger provider and, when enabled, to the console.
+func newLogger(cfg config.Config, lp *sdklog.LoggerProvider) (*logrus.Logger, error) {
+	level, err := logrus.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse log level: %w", err)
+	}
+
+	output, err := newConsoleOutput(cfg.LogConsole)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create an *otellogrus.Hook and use it in your application.
+	hook := otellogrus.NewHook(cfg.ServiceName, otellogrus.WithLoggerProvider(lp))
+	// Set the newly created hook as a global logrus hook
+	log := logrus.New()
+	log.AddHook(hook)
+	log.SetLevel(level)
+	log.SetOutput(output)
+	log.ExitFunc = exitFunc
+
+	switch strings.ToLower(cfg.LogConsoleFormat) {
+	case "json":
+		log.SetFormatter(&consoleFormatter{&logrus.JSONFormatter{}})
+	case "", "text":
+		log.SetFormatter(&consoleFormatter{&logrus.TextFormatter{FullTimestamp: true}})
+	default:
+		return nil, fmt.Errorf("unknown console log format: %s", cfg.LogConsoleFormat)
+	}
+
+	return log, nil
+}
+
+// exitFunc exports the fatal log record and the buffered telemetry before exiting.
+func exitFunc(code int) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout())
+	defer cancel()
+
+	// shut down every provider even when one of them fails, the process is exiting
+	if logprovider != nil {
+		_ = logprovider.Shutdown(ctx)
+	}
+	if tracerprovider != nil {
+		_ = tracerprovider.Shutdown(ctx)
+	}
+	if meterprovider != nil {
+		_ = meterprovider.Shutdown(ctx)
+	}
+	os.Exit(code)
+}
+
+// flushTelemetry exports the buffered telemetry without shutting down the providers.
+func flushTelemetry() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout())
+	defer cancel()
+
+	if logprovider != nil {
+		_ = logprovider.ForceFlush(ctx)
+	}
+	if tracerprovider != nil {
+		_ = tracerprovider.ForceFlush(ctx)
+	}
+	if meterprovider != nil {
+		_ = meterprovider.ForceFlush(ctx)
+	}
+}
+
+// FlushOnPanic exports the buffered telemetry, within the shutdown timeout, when the calling
+// goroutine is panicking and lets the panic continue. Defer it first in main and in goroutines
+// started with the go statement so the records of LogPanic and of an unrecovered panic are not
+// lost; recovered panics do not wait for the export. Go and Group.Go recover and record the
+// panics of their goroutines and do not need it.
+func FlushOnPanic() {
+	if r := recover(); r != nil {
+		flushTelemetry()
+		panic(r)
+	}
+}
+
+// shutdownTimeout returns the time allowed to export the telemetry before the process terminates.
+func shutdownTimeout() time.Duration {
+	if tracingConfig.ShutdownTimeoutMs <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(tracingConfig.ShutdownTimeoutMs) * time.Millisecond
+}
+
+// OtelLogger returns a logger of the logger provider created by InitTracer, for adapters
+// of other logging libraries sharing the resource and exporter configuration.
+func OtelLogger() otellog.Logger {
+	return logprovider.Logger(tracingConfig.ServiceName)
+}
+
+// FlushLogs exports the buffered log records of the logger provider created by InitTracer,
+// within the shutdown timeout, for adapters of other logging libraries syncing their output.
+func FlushLogs() error {
+	if logprovider == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout())
+	defer cancel()
+	if err := logprovider.ForceFlush(ctx); err != nil {
+		return fmt.Errorf("failed to flush logs: %w", err)
+	}
+	return nil
+}
+
+// LogLevel returns the current minimum log level, without the per-package overrides.
+func LogLevel() logrus.Level {
+	if levels := loglevels.Load(); levels != nil {
+		return levels.base
+	}
+	return loger.GetLevel()
+}
+
+// newConsoleOutput returns the console writer for "stdout" or "stderr", or a writer discarding everything.
+func newConsoleOutput(name string) (io.Writer, error) {
+	switch strings.ToLower(name) {
+	case "":
+		return &noopWriter{}, nil
+	case "stdout":
+		return os.Stdout, nil
+	case "stderr":
+		return os.Stderr, nil
+	default:
+		return nil, fmt.Errorf("unknown console log output: %s", name)
+	}
+}
+
+// consoleFormatter adds the trace_id and span_id of the entry context to console lines.
+type consoleFormatter struct {
+	logrus.Formatter
+}
+
+func (f *consoleFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if entry.Context == nil {
+		return f.Formatter.Format(entry)
+	}
+	sc := oteltrace.SpanContextFromContext(entry.Context)
+	if !sc.IsValid() {
+		return f.Formatter.Format(entry)
+	}
+
+	dup := *entry
+	dup.Data = make(logrus.Fields, len(entry.Data)+2)
+	for k, v := range entry.Data {
+		dup.Data[k] = v
+	}
+	dup.Data["trace_id"] = sc.TraceID().String()
+	dup.Data["span_id"] = sc.SpanID().String()
+	return f.Formatter.Format(&dup)
+}
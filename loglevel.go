@@ -0,0 +1,182 @@
+package otelTracing
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/faizal-asep-outlook/otel-tracing/config"
+	"github.com/sirupsen/logrus"
+)
+
+// logLevels holds the base log level and the overrides keyed by caller package path.
+// It is never modified once published, updates replace it.
+type logLevels struct {
+	base      logrus.Level
+	overrides map[string]logrus.Level
+}
+
+var (
+	loglevels   atomic.Pointer[logLevels]
+	loglevelsMu sync.Mutex
+)
+
+// newLogLevels creates the log levels from the config, the overrides of the file are
+// applied after the ones of the environment.
+func newLogLevels(cfg config.Config) (*logLevels, error) {
+	base, err := logrus.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse log level: %w", err)
+	}
+
+	levels := &logLevels{base: base, overrides: map[string]logrus.Level{}}
+	if err := parseLogLevelOverrides(cfg.LogLevelOverrides, levels.overrides); err != nil {
+		return nil, err
+	}
+	if cfg.LogLevelOverridesFile != "" {
+		data, err := os.ReadFile(cfg.LogLevelOverridesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read log level overrides: %w", err)
+		}
+		if err := parseLogLevelOverrides(string(data), levels.overrides); err != nil {
+			return nil, err
+		}
+	}
+
+	return levels, nil
+}
+
+// parseLogLevelOverrides parses "package=level" entries separated by commas or new lines,
+// lines starting with # are ignored.
+func parseLogLevelOverrides(raw string, overrides map[string]logrus.Level) error {
+	for _, line := range strings.Split(raw, "\n") {
+		if line = strings.TrimSpace(line); line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, entry := range strings.Split(line, ",") {
+			if entry = strings.TrimSpace(entry); entry == "" {
+				continue
+			}
+			pkg, name, ok := strings.Cut(entry, "=")
+			if !ok {
+				return fmt.Errorf("invalid log level override: %s", entry)
+			}
+			level, err := logrus.ParseLevel(strings.TrimSpace(name))
+			if err != nil {
+				return fmt.Errorf("failed to parse log level override: %w", err)
+			}
+			overrides[strings.TrimSpace(pkg)] = level
+		}
+	}
+	return nil
+}
+
+// setLogLevels publishes the log levels. The logrus logger is set to the most verbose
+// level so the records enabled by an override reach the hooks, the logger handed out by
+// LoggerFromContext keeps the base level.
+func setLogLevels(levels *logLevels) {
+	lowest := levels.base
+	for _, level := range levels.overrides {
+		if level > lowest {
+			lowest = level
+		}
+	}
+	loglevels.Store(levels)
+	loger.SetLevel(lowest)
+	baseloger.SetLevel(levels.base)
+}
+
+// updateLogLevels applies fn to a copy of the current log levels and publishes it.
+func updateLogLevels(fn func(levels *logLevels)) {
+	loglevelsMu.Lock()
+	defer loglevelsMu.Unlock()
+
+	current := loglevels.Load()
+	levels := &logLevels{base: current.base, overrides: make(map[string]logrus.Level, len(current.overrides)+1)}
+	for pkg, level := range current.overrides {
+		levels.overrides[pkg] = level
+	}
+	fn(levels)
+	setLogLevels(levels)
+}
+
+// SetLogLevel changes the base log level at runtime.
+func SetLogLevel(level logrus.Level) {
+	updateLogLevels(func(levels *logLevels) {
+		levels.base = level
+	})
+}
+
+// SetLogLevelOverride changes at runtime the log level of the callers in the package path
+// pkg and its sub packages.
+func SetLogLevelOverride(pkg string, level logrus.Level) {
+	updateLogLevels(func(levels *logLevels) {
+		levels.overrides[pkg] = level
+	})
+}
+
+// RemoveLogLevelOverride removes at runtime the log level override of the package path pkg.
+func RemoveLogLevelOverride(pkg string) {
+	updateLogLevels(func(levels *logLevels) {
+		delete(levels.overrides, pkg)
+	})
+}
+
+// FunctionLogLevel returns the current log level of the callers in function, a full function name
+// such as "github.com/a/b/pkg.(*T).Method", or the base level when function is empty.
+func FunctionLogLevel(function string) logrus.Level {
+	levels := loglevels.Load()
+	if levels == nil {
+		return loger.GetLevel()
+	}
+	if function == "" {
+		return levels.base
+	}
+	namespace, _ := splitFunctionName(function)
+	return levels.levelFor(namespace)
+}
+
+// VerboseLogLevel returns the current most verbose of the base log level and the overrides, the
+// records below it are disabled for every package.
+func VerboseLogLevel() logrus.Level {
+	return loger.GetLevel()
+}
+
+// levelFor returns the log level of a caller package, the longest matching override wins.
+func (l *logLevels) levelFor(namespace string) logrus.Level {
+	level, matched := l.base, -1
+	for pkg, override := range l.overrides {
+		if len(pkg) > matched && (namespace == pkg || strings.HasPrefix(namespace, pkg+"/")) {
+			level, matched = override, len(pkg)
+		}
+	}
+	return level
+}
+
+// logLevelEnabled reports whether a record at level is enabled for the call site skip frames
+// above the caller of logLevelEnabled.
+func logLevelEnabled(level logrus.Level, skip int) bool {
+	levels := loglevels.Load()
+	if levels == nil {
+		return loger.IsLevelEnabled(level)
+	}
+	if len(levels.overrides) == 0 {
+		return level <= levels.base
+	}
+	return level <= levels.levelFor(resolveCaller(skip+1).namespace)
+}
+
+// logLevelEnabledPC reports whether a record at level is enabled for the caller at pc,
+// a zero pc uses the base level.
+func logLevelEnabledPC(level logrus.Level, pc uintptr) bool {
+	levels := loglevels.Load()
+	if levels == nil {
+		return loger.IsLevelEnabled(level)
+	}
+	if len(levels.overrides) == 0 || pc == 0 {
+		return level <= levels.base
+	}
+	return level <= levels.levelFor(callerFromPC(pc).namespace)
+}
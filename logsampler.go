@@ -0,0 +1,144 @@
+package otelTracing
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"sync/atomic"
+	"time"
+
+	"github.com/faizal-asep-outlook/otel-tracing/config"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// MetricLogsSuppressed is a metric that counts the log records dropped by the log sampler.
+var MetricLogsSuppressed = Metric{
+	Name:        "logs_suppressed_total",
+	Unit:        "{count}",
+	Description: "Total number of log records suppressed by the log sampler",
+}
+
+// logSamplerBuckets is the number of counters per level, the keys are hashed into them.
+const logSamplerBuckets = 4096
+
+var logsampler *logSampler
+
+// logSampler keeps, per key and tick, the first records then every Mth record. The key is the
+// message template, or the call site of the records logged without one.
+type logSampler struct {
+	tick        int64
+	first       uint64
+	thereafter  uint64
+	keepLevel   logrus.Level
+	keepSampled bool
+	counters    [logrus.TraceLevel + 1][logSamplerBuckets]logSamplerCounter
+	suppressed  otelmetric.Int64Counter
+}
+
+// logSamplerCounter counts the records of a key within the current tick.
+type logSamplerCounter struct {
+	resetAt atomic.Int64
+	count   atomic.Uint64
+}
+
+// newLogSampler creates the log sampler, or returns nil when log sampling is disabled.
+func newLogSampler(cfg config.Config, m otelmetric.Meter) (*logSampler, error) {
+	if !cfg.LogSamplingEnabled {
+		return nil, nil
+	}
+
+	keepLevel, err := logrus.ParseLevel(cfg.LogSamplingKeepLevel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse log sampling keep level: %w", err)
+	}
+	suppressed, err := m.Int64Counter(
+		MetricLogsSuppressed.Name,
+		otelmetric.WithDescription(MetricLogsSuppressed.Description),
+		otelmetric.WithUnit(MetricLogsSuppressed.Unit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create suppressed logs counter: %w", err)
+	}
+
+	tick := time.Duration(cfg.LogSamplingTickMs) * time.Millisecond
+	if tick <= 0 {
+		tick = time.Second
+	}
+	first := cfg.LogSamplingFirst
+	if first < 0 {
+		first = 0
+	}
+	thereafter := cfg.LogSamplingThereafter
+	if thereafter < 0 {
+		thereafter = 0
+	}
+
+	return &logSampler{
+		tick:        int64(tick),
+		first:       uint64(first),
+		thereafter:  uint64(thereafter),
+		keepLevel:   keepLevel,
+		keepSampled: cfg.LogSamplingKeepSampledTraces,
+		suppressed:  suppressed,
+	}, nil
+}
+
+// allow reports whether a record of the key should be logged, counting the suppressed ones.
+func (s *logSampler) allow(ctx context.Context, level logrus.Level, key uint64) bool {
+	// fatal and panic records are always kept, they end the process or the goroutine
+	if level <= s.keepLevel || level <= logrus.FatalLevel {
+		return true
+	}
+	if s.keepSampled && oteltrace.SpanContextFromContext(ctx).IsSampled() {
+		return true
+	}
+	if int(level) >= len(s.counters) {
+		return true
+	}
+
+	counter := &s.counters[level][key%logSamplerBuckets]
+
+	n := counter.inc(time.Now().UnixNano(), s.tick)
+	if n <= s.first || (s.thereafter > 0 && (n-s.first)%s.thereafter == 0) {
+		return true
+	}
+
+	s.suppressed.Add(context.Background(), 1, otelmetric.WithAttributes(
+		attribute.String("level", level.String()),
+	))
+	return false
+}
+
+// templateKey returns the sampling key of a message template.
+func templateKey(template string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(template))
+	return h.Sum64()
+}
+
+// callSiteKey returns the sampling key of a call site.
+func callSiteKey(pc uintptr) uint64 {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(pc))
+	h := fnv.New64a()
+	h.Write(b[:])
+	return h.Sum64()
+}
+
+// inc increments the counter, resetting it when the tick it was counting in has passed.
+func (c *logSamplerCounter) inc(now, tick int64) uint64 {
+	resetAt := c.resetAt.Load()
+	if resetAt > now {
+		return c.count.Add(1)
+	}
+	if !c.resetAt.CompareAndSwap(resetAt, now+tick) {
+		// another caller starts the new tick, count this record in it
+		return c.count.Add(1)
+	}
+	c.count.Store(1)
+	return 1
+}
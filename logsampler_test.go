@@ -0,0 +1,116 @@
+package otelTracing
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/faizal-asep-outlook/otel-tracing/config"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// countHook counts the fired log entries.
+type countHook struct {
+	count int
+}
+
+func (h *countHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *countHook) Fire(*logrus.Entry) error {
+	h.count++
+	return nil
+}
+
+func TestLogSamplerVariableMessages(t *testing.T) {
+	sampler, err := newLogSampler(config.Config{
+		LogSamplingEnabled:    true,
+		LogSamplingTickMs:     60000,
+		LogSamplingFirst:      5,
+		LogSamplingThereafter: 100,
+		LogSamplingKeepLevel:  "error",
+	}, noop.NewMeterProvider().Meter("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hook := &countHook{}
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	log.AddHook(hook)
+
+	savedLoger, savedSampler := loger, logsampler
+	loger, logsampler = log, sampler
+	defer func() {
+		loger, logsampler = savedLoger, savedSampler
+	}()
+
+	ctx := context.Background()
+	for i := 0; i < 1000; i++ {
+		LogWarn(ctx, "retry failed for item ", i)
+	}
+	// the first 5 records, then the 105th, 205th ... 905th
+	if hook.count != 14 {
+		t.Errorf("LogWarn kept %d records, want 14", hook.count)
+	}
+
+	hook.count = 0
+	for i := 0; i < 1000; i++ {
+		LogWarnf(ctx, "retry failed for item %d", i)
+	}
+	if hook.count != 14 {
+		t.Errorf("LogWarnf kept %d records, want 14", hook.count)
+	}
+}
+
+func TestLogSamplerCounterTickBoundary(t *testing.T) {
+	const (
+		callers = 8
+		calls   = 4
+	)
+	tick := int64(time.Minute)
+
+	for round := 0; round < 1000; round++ {
+		var counter logSamplerCounter
+		// a previous tick that has just passed, its count far from the counts of the new tick
+		counter.resetAt.Store(1000)
+		counter.count.Store(1 << 32)
+
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		results := make([][]uint64, callers)
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				<-start
+				for j := 0; j < calls; j++ {
+					results[i] = append(results[i], counter.inc(1000, tick))
+				}
+			}(i)
+		}
+		close(start)
+		wg.Wait()
+
+		// a counter reset in the middle of the tick returns the same counts again
+		seen := map[uint64]bool{}
+		for _, counts := range results {
+			for _, n := range counts {
+				if seen[n] {
+					t.Fatalf("round %d: count %d returned twice", round, n)
+				}
+				seen[n] = true
+			}
+		}
+		if !seen[1] {
+			t.Fatalf("round %d: the tick was not started", round)
+		}
+		if got := counter.resetAt.Load(); got != 1000+tick {
+			t.Fatalf("round %d: reset at %d, want %d", round, got, 1000+tick)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package otelTracing
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -15,8 +16,67 @@ import (
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
+// ginContextKey is the gin key holding the request context carrying the server span.
+const ginContextKey = "otel-tracing/context"
+
+// requestContext returns the request context carrying the server span, even when the
+// calling middleware runs outside of MiddlewareGinTrace.
+func requestContext(c *gin.Context) context.Context {
+	if v, ok := c.Get(ginContextKey); ok {
+		if ctx, ok := v.(context.Context); ok {
+			return ctx
+		}
+	}
+	return c.Request.Context()
+}
+
+// GinTraceOption configures MiddlewareGinTrace.
+type GinTraceOption func(*ginTraceConfig)
+
+type ginTraceConfig struct {
+	traceIDHeader     string
+	traceResponse     bool
+	spanNameFormatter func(*gin.Context) string
+}
+
+// WithTraceIDResponseHeader writes the trace ID of the request span into the given response header.
+func WithTraceIDResponseHeader(name string) GinTraceOption {
+	return func(cfg *ginTraceConfig) {
+		cfg.traceIDHeader = name
+	}
+}
+
+// WithTraceResponseHeader writes the W3C traceresponse header for the request span.
+func WithTraceResponseHeader() GinTraceOption {
+	return func(cfg *ginTraceConfig) {
+		cfg.traceResponse = true
+	}
+}
+
+// WithSpanNameFormatter names the request spans with formatter, the default name is used when it
+// returns an empty string.
+func WithSpanNameFormatter(formatter func(c *gin.Context) string) GinTraceOption {
+	return func(cfg *ginTraceConfig) {
+		cfg.spanNameFormatter = formatter
+	}
+}
+
+// defaultSpanName names the request span "METHOD route" from the route template, so the name
+// does not depend on the path parameters and the query.
+func defaultSpanName(c *gin.Context) string {
+	route := c.FullPath()
+	if route == "" {
+		return fmt.Sprintf("HTTP %s route not found", c.Request.Method)
+	}
+	return c.Request.Method + " " + route
+}
+
 // MiddlewareTrace ginMiddleware.
-func MiddlewareGinTrace() gin.HandlerFunc {
+func MiddlewareGinTrace(opts ...GinTraceOption) gin.HandlerFunc {
+	cfg := ginTraceConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
 	Propagators := otel.GetTextMapPropagator()
 	return func(c *gin.Context) {
 
@@ -29,26 +89,29 @@ func MiddlewareGinTrace() gin.HandlerFunc {
 			oteltrace.WithAttributes(semconv.HTTPRoute(c.FullPath())),
 			oteltrace.WithSpanKind(oteltrace.SpanKindServer),
 		}
-		scheme := "http"
-		if c.Request.TLS != nil {
-			scheme = "https"
-		}
-
-		path := fmt.Sprintf("%s://%s%s", scheme, c.Request.Host, c.Request.URL.Path)
-		raw := c.Request.URL.RawQuery
-		if raw != "" {
-			path = path + "?" + raw
+		var spanName string
+		if cfg.spanNameFormatter != nil {
+			spanName = cfg.spanNameFormatter(c)
 		}
-
-		spanName := path
 		if spanName == "" {
-			spanName = fmt.Sprintf("HTTP %s route not found", c.Request.Method)
+			spanName = defaultSpanName(c)
 		}
 		ctx, span := Tracer.Start(ctx, spanName, opts...)
 		defer span.End()
 
 		// pass the span through the request context
 		c.Request = c.Request.WithContext(ctx)
+		c.Set(ginContextKey, ctx)
+
+		// let the client report the trace, headers must be written before the handlers
+		if sc := span.SpanContext(); sc.IsValid() {
+			if cfg.traceIDHeader != "" {
+				c.Header(cfg.traceIDHeader, sc.TraceID().String())
+			}
+			if cfg.traceResponse {
+				c.Header("traceresponse", EncodeSpanContext(sc))
+			}
+		}
 
 		// serve the request to the next middleware
 		c.Next()
@@ -80,6 +143,21 @@ func MiddlewareLogger() gin.HandlerFunc {
 		path := fmt.Sprintf("%s://%s%s", scheme, c.Request.Host, c.Request.URL.Path)
 		raw := c.Request.URL.RawQuery
 
+		// seed the request logger, the handlers log these fields with the request context
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		fields := log.Fields{
+			"request_id": requestID,
+			"route":      c.FullPath(),
+			"client_ip":  c.ClientIP(),
+		}
+		c.Request = c.Request.WithContext(WithLogFields(c.Request.Context(), fields))
+		if ctx, ok := c.Get(ginContextKey); ok {
+			c.Set(ginContextKey, WithLogFields(ctx.(context.Context), fields))
+		}
+
 		// Process request
 		c.Next()
 
@@ -101,7 +179,10 @@ func MiddlewareLogger() gin.HandlerFunc {
 		}
 
 		// param.Path = path
-		loger.WithContext(c.Request.Context()).WithFields(param).Info(path)
+		if logLevelEnabled(log.InfoLevel, 0) {
+			ctx := requestContext(c)
+			loger.WithContext(ctx).WithFields(contextLogFields(ctx)).WithFields(param).Info(path)
+		}
 		// fmt.Fprint(out, formatter(param))
 
 	}
@@ -146,17 +227,18 @@ func MiddlewareMeter() gin.HandlerFunc {
 			semconv.HTTPMethod(c.Request.Method),
 			semconv.HTTPStatusCode(c.Writer.Status()),
 		)
-		// record the request duration
+		// record the request duration inside the span context so exemplars link to the trace
+		ctx := requestContext(c)
 		duration := time.Since(startTime)
 		histogram.Record(
-			c.Request.Context(),
+			ctx,
 			duration.Milliseconds(),
 			attrs,
 		)
 
 		// decrease the number of requests in flight
-		counter.Add(c.Request.Context(), -1, attrs)
-		totalCounter.Add(c.Request.Context(), 1, attrs)
+		counter.Add(ctx, -1, attrs)
+		totalCounter.Add(ctx, 1, attrs)
 	}
 }
 
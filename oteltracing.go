@@ -3,18 +3,22 @@ package otelTracing
 import (
 	"context"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 type otelTracing struct {
 }
 
-func (t *otelTracing) MiddlewareGinTrace() gin.HandlerFunc {
-	return MiddlewareGinTrace()
+func (t *otelTracing) MiddlewareGinTrace(opts ...GinTraceOption) gin.HandlerFunc {
+	return MiddlewareGinTrace(opts...)
 }
 
 func (t *otelTracing) MiddlewareLogger() gin.HandlerFunc {
@@ -26,40 +30,209 @@ func (t *otelTracing) TraceStart(ctx context.Context, name string) (context.Cont
 	return TraceStart(ctx, name)
 }
 
+// TraceStartWithOptions starts a new span with the given name and options. The span must be ended by calling End.
+func (t *otelTracing) TraceStartWithOptions(ctx context.Context, name string, opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+	return TraceStartWithOptions(ctx, name, opts...)
+}
+
+// TraceStartClient starts a new client span. The span must be ended by calling End.
+func (t *otelTracing) TraceStartClient(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, oteltrace.Span) {
+	return TraceStartClient(ctx, name, attrs...)
+}
+
+// TraceStartProducer starts a new producer span. The span must be ended by calling End.
+func (t *otelTracing) TraceStartProducer(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, oteltrace.Span) {
+	return TraceStartProducer(ctx, name, attrs...)
+}
+
+// TraceStartConsumer starts a new consumer span. The span must be ended by calling End.
+func (t *otelTracing) TraceStartConsumer(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, oteltrace.Span) {
+	return TraceStartConsumer(ctx, name, attrs...)
+}
+
+// TraceStartInternal starts a new internal span. The span must be ended by calling End.
+func (t *otelTracing) TraceStartInternal(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, oteltrace.Span) {
+	return TraceStartInternal(ctx, name, attrs...)
+}
+
+// TraceStartAuto starts a new span named after the calling function. The span must be ended by calling End.
+func (t *otelTracing) TraceStartAuto(ctx context.Context, opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+	return traceStartAuto(ctx, 1, opts)
+}
+
+// Trace runs fn inside a new span with the given name, recording its error or panic.
+func (t *otelTracing) Trace(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	return Trace(ctx, name, fn)
+}
+
+// Go runs fn in a new goroutine inside a span with the given name.
+func (t *otelTracing) Go(ctx context.Context, name string, fn func(ctx context.Context) error) {
+	Go(ctx, name, fn)
+}
+
+// NewGroup starts a span with the given name for a new Group of traced goroutines.
+func (t *otelTracing) NewGroup(ctx context.Context, name string) (*Group, context.Context) {
+	return NewGroup(ctx, name)
+}
+
 func (t *otelTracing) ShutDown(ctx context.Context) error {
 	return ShutDown(ctx)
 }
 
+// Inject injects the span context and baggage of ctx into carrier.
+func (t *otelTracing) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	Inject(ctx, carrier)
+}
+
+// Extract returns a copy of ctx with the span context and baggage extracted from carrier.
+func (t *otelTracing) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return Extract(ctx, carrier)
+}
+
+// StartLinkedRoot starts a new root span linked to the remote span context. The span must be ended by calling End.
+func (t *otelTracing) StartLinkedRoot(ctx context.Context, name string, remote oteltrace.SpanContext, opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+	return StartLinkedRoot(ctx, name, remote, opts...)
+}
+
+// TraceIDFromContext returns the trace ID of the span in ctx.
+func (t *otelTracing) TraceIDFromContext(ctx context.Context) string {
+	return TraceIDFromContext(ctx)
+}
+
+// SpanIDFromContext returns the span ID of the span in ctx.
+func (t *otelTracing) SpanIDFromContext(ctx context.Context) string {
+	return SpanIDFromContext(ctx)
+}
+
+// SetBaggage returns a copy of ctx with the baggage member key set to value.
+func (t *otelTracing) SetBaggage(ctx context.Context, key, value string) (context.Context, error) {
+	return SetBaggage(ctx, key, value)
+}
+
+// GetBaggage returns the value of the baggage member key in ctx.
+func (t *otelTracing) GetBaggage(ctx context.Context, key string) string {
+	return GetBaggage(ctx, key)
+}
+
+func (t *otelTracing) WithLogFields(ctx context.Context, fields logrus.Fields) context.Context {
+	return WithLogFields(ctx, fields)
+}
+
+func (t *otelTracing) LoggerFromContext(ctx context.Context) *logrus.Entry {
+	return LoggerFromContext(ctx)
+}
+
 func (t *otelTracing) LogTrace(ctx context.Context, args ...interface{}) {
-	LogTrace(ctx, args...)
+	logArgs(ctx, 1, logrus.TraceLevel, args)
 }
 
 func (t *otelTracing) LogDebug(ctx context.Context, args ...interface{}) {
-	LogDebug(ctx, args...)
+	logArgs(ctx, 1, logrus.DebugLevel, args)
 }
 
 func (t *otelTracing) LogPrint(ctx context.Context, args ...interface{}) {
-	LogPrint(ctx, args...)
+	logArgs(ctx, 1, logrus.InfoLevel, args)
 }
 
 func (t *otelTracing) LogInfo(ctx context.Context, args ...interface{}) {
-	LogInfo(ctx, args...)
+	logArgs(ctx, 1, logrus.InfoLevel, args)
 }
 
 func (t *otelTracing) LogWarn(ctx context.Context, args ...interface{}) {
-	LogWarn(ctx, args...)
+	logArgs(ctx, 1, logrus.WarnLevel, args)
 }
 
 func (t *otelTracing) LogError(ctx context.Context, args ...interface{}) {
-	LogError(ctx, args...)
+	logArgs(ctx, 1, logrus.ErrorLevel, args)
 }
 
 func (t *otelTracing) LogFatal(ctx context.Context, args ...interface{}) {
-	LogFatal(ctx, args...)
+	logArgs(ctx, 1, logrus.FatalLevel, args)
 }
 
 func (t *otelTracing) LogPanic(ctx context.Context, args ...interface{}) {
-	LogPanic(ctx, args...)
+	logArgs(ctx, 1, logrus.PanicLevel, args)
+}
+
+func (t *otelTracing) LogTracef(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, 1, logrus.TraceLevel, format, args)
+}
+
+func (t *otelTracing) LogDebugf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, 1, logrus.DebugLevel, format, args)
+}
+
+func (t *otelTracing) LogPrintf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, 1, logrus.InfoLevel, format, args)
+}
+
+func (t *otelTracing) LogInfof(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, 1, logrus.InfoLevel, format, args)
+}
+
+func (t *otelTracing) LogWarnf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, 1, logrus.WarnLevel, format, args)
+}
+
+func (t *otelTracing) LogErrorf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, 1, logrus.ErrorLevel, format, args)
+}
+
+func (t *otelTracing) LogFatalf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, 1, logrus.FatalLevel, format, args)
+}
+
+func (t *otelTracing) LogPanicf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, 1, logrus.PanicLevel, format, args)
+}
+
+func (t *otelTracing) LogTraceKV(ctx context.Context, msg string, keyvals ...interface{}) {
+	logKV(ctx, 1, logrus.TraceLevel, msg, keyvals)
+}
+
+func (t *otelTracing) LogDebugKV(ctx context.Context, msg string, keyvals ...interface{}) {
+	logKV(ctx, 1, logrus.DebugLevel, msg, keyvals)
+}
+
+func (t *otelTracing) LogPrintKV(ctx context.Context, msg string, keyvals ...interface{}) {
+	logKV(ctx, 1, logrus.InfoLevel, msg, keyvals)
+}
+
+func (t *otelTracing) LogInfoKV(ctx context.Context, msg string, keyvals ...interface{}) {
+	logKV(ctx, 1, logrus.InfoLevel, msg, keyvals)
+}
+
+func (t *otelTracing) LogWarnKV(ctx context.Context, msg string, keyvals ...interface{}) {
+	logKV(ctx, 1, logrus.WarnLevel, msg, keyvals)
+}
+
+func (t *otelTracing) LogErrorKV(ctx context.Context, msg string, keyvals ...interface{}) {
+	logKV(ctx, 1, logrus.ErrorLevel, msg, keyvals)
+}
+
+func (t *otelTracing) LogFatalKV(ctx context.Context, msg string, keyvals ...interface{}) {
+	logKV(ctx, 1, logrus.FatalLevel, msg, keyvals)
+}
+
+func (t *otelTracing) LogPanicKV(ctx context.Context, msg string, keyvals ...interface{}) {
+	logKV(ctx, 1, logrus.PanicLevel, msg, keyvals)
+}
+
+// Slog returns a *slog.Logger exporting through the logger provider.
+func (t *otelTracing) Slog() *slog.Logger {
+	return SlogLogger()
+}
+
+func (t *otelTracing) SetLogLevel(level logrus.Level) {
+	SetLogLevel(level)
+}
+
+func (t *otelTracing) SetLogLevelOverride(pkg string, level logrus.Level) {
+	SetLogLevelOverride(pkg, level)
+}
+
+func (t *otelTracing) RemoveLogLevelOverride(pkg string) {
+	RemoveLogLevelOverride(pkg)
 }
 
 func (t *otelTracing) HttpDo(ctx context.Context, req *http.Request) (*http.Response, error) {
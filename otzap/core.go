@@ -0,0 +1,221 @@
+// Package otzap provides a zap core exporting log entries through the logger provider
+// of otel-tracing, so zap loggers share its resource and exporter configuration.
+package otzap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ot "github.com/faizal-asep-outlook/otel-tracing"
+	"github.com/sirupsen/logrus"
+	otellog "go.opentelemetry.io/otel/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// contextKey is the key of the field carrying the context of an entry.
+const contextKey = "context"
+
+// contextField is the value of the field carrying the context of an entry.
+type contextField struct {
+	ctx context.Context
+}
+
+// Context returns a field carrying ctx, the trace context of ctx is attached to the exported entry.
+// The field is skipped by the other cores and encoders.
+func Context(ctx context.Context) zap.Field {
+	return zap.Field{Key: contextKey, Type: zapcore.SkipType, Interface: contextField{ctx: ctx}}
+}
+
+// core is a zapcore.Core exporting entries through the logger provider.
+type core struct {
+	zapcore.LevelEnabler
+	logger otellog.Logger
+	attrs  []otellog.KeyValue
+	ctx    context.Context
+}
+
+// NewCore creates a zapcore.Core exporting entries through the logger provider created by
+// InitTracer, enabled from the current log level of the caller package when the logger adds the
+// caller, or from the base log level. It must be created after InitTracer.
+func NewCore() zapcore.Core {
+	return &core{
+		// the caller is not known yet when checking, the level of the caller is checked in Write
+		LevelEnabler: zap.LevelEnablerFunc(func(level zapcore.Level) bool {
+			return level >= zapLevel(ot.VerboseLogLevel())
+		}),
+		logger: ot.OtelLogger(),
+		ctx:    context.Background(),
+	}
+}
+
+// New creates a *zap.Logger using NewCore.
+func New(options ...zap.Option) *zap.Logger {
+	return zap.New(NewCore(), options...)
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	dup := *c
+	dup.attrs = append([]otellog.KeyValue{}, c.attrs...)
+	dup.ctx, dup.attrs = convertFields(c.ctx, dup.attrs, fields)
+	return &dup
+}
+
+func (c *core) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	var function string
+	if entry.Caller.Defined {
+		function = entry.Caller.Function
+	}
+	if entry.Level < zapLevel(ot.FunctionLogLevel(function)) {
+		return nil
+	}
+
+	var record otellog.Record
+	record.SetTimestamp(entry.Time)
+	record.SetBody(otellog.StringValue(entry.Message))
+	record.SetSeverity(severity(entry.Level))
+	record.SetSeverityText(entry.Level.String())
+	record.AddAttributes(c.attrs...)
+
+	if entry.LoggerName != "" {
+		record.AddAttributes(otellog.String("logger", entry.LoggerName))
+	}
+	if entry.Caller.Defined {
+		record.AddAttributes(
+			otellog.String(string(semconv.CodeFunctionKey), entry.Caller.Function),
+			otellog.String(string(semconv.CodeFilepathKey), entry.Caller.File),
+			otellog.Int(string(semconv.CodeLineNumberKey), entry.Caller.Line),
+		)
+	}
+	if entry.Stack != "" {
+		record.AddAttributes(otellog.String(string(semconv.ExceptionStacktraceKey), entry.Stack))
+	}
+
+	ctx, attrs := convertFields(c.ctx, nil, fields)
+	record.AddAttributes(attrs...)
+	c.logger.Emit(ctx, record)
+	return nil
+}
+
+func (c *core) Sync() error {
+	return ot.FlushLogs()
+}
+
+// convertFields appends the fields to attrs, a field carrying a context replaces ctx.
+func convertFields(ctx context.Context, attrs []otellog.KeyValue, fields []zapcore.Field) (context.Context, []otellog.KeyValue) {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		if field, ok := f.Interface.(contextField); ok && f.Type == zapcore.SkipType {
+			ctx = field.ctx
+			continue
+		}
+		f.AddTo(enc)
+	}
+	for key, value := range enc.Fields {
+		attrs = append(attrs, otellog.KeyValue{Key: key, Value: convertValue(value)})
+	}
+	return ctx, attrs
+}
+
+// convertValue converts a value encoded by zapcore.MapObjectEncoder to a log value keeping its type.
+func convertValue(v interface{}) otellog.Value {
+	switch value := v.(type) {
+	case string:
+		return otellog.StringValue(value)
+	case bool:
+		return otellog.BoolValue(value)
+	case int:
+		return otellog.IntValue(value)
+	case int8:
+		return otellog.Int64Value(int64(value))
+	case int16:
+		return otellog.Int64Value(int64(value))
+	case int32:
+		return otellog.Int64Value(int64(value))
+	case int64:
+		return otellog.Int64Value(value)
+	case uint:
+		return otellog.Int64Value(int64(value))
+	case uint8:
+		return otellog.Int64Value(int64(value))
+	case uint16:
+		return otellog.Int64Value(int64(value))
+	case uint32:
+		return otellog.Int64Value(int64(value))
+	case uint64:
+		return otellog.Int64Value(int64(value))
+	case float32:
+		return otellog.Float64Value(float64(value))
+	case float64:
+		return otellog.Float64Value(value)
+	case []byte:
+		return otellog.BytesValue(value)
+	case time.Duration:
+		return otellog.Int64Value(value.Nanoseconds())
+	case time.Time:
+		return otellog.StringValue(value.Format(time.RFC3339Nano))
+	case map[string]interface{}:
+		kvs := make([]otellog.KeyValue, 0, len(value))
+		for k, item := range value {
+			kvs = append(kvs, otellog.KeyValue{Key: k, Value: convertValue(item)})
+		}
+		return otellog.MapValue(kvs...)
+	case []interface{}:
+		values := make([]otellog.Value, 0, len(value))
+		for _, item := range value {
+			values = append(values, convertValue(item))
+		}
+		return otellog.SliceValue(values...)
+	default:
+		return otellog.StringValue(fmt.Sprint(value))
+	}
+}
+
+// severity maps a zap level to the matching log severity.
+func severity(level zapcore.Level) otellog.Severity {
+	switch level {
+	case zapcore.DebugLevel:
+		return otellog.SeverityDebug
+	case zapcore.InfoLevel:
+		return otellog.SeverityInfo
+	case zapcore.WarnLevel:
+		return otellog.SeverityWarn
+	case zapcore.ErrorLevel:
+		return otellog.SeverityError
+	case zapcore.DPanicLevel:
+		return otellog.SeverityError2
+	case zapcore.PanicLevel:
+		return otellog.SeverityError3
+	case zapcore.FatalLevel:
+		return otellog.SeverityFatal
+	default:
+		return otellog.SeverityUndefined
+	}
+}
+
+// zapLevel maps a logrus level to the matching zap level.
+func zapLevel(level logrus.Level) zapcore.Level {
+	switch level {
+	case logrus.TraceLevel, logrus.DebugLevel:
+		return zapcore.DebugLevel
+	case logrus.InfoLevel:
+		return zapcore.InfoLevel
+	case logrus.WarnLevel:
+		return zapcore.WarnLevel
+	case logrus.ErrorLevel:
+		return zapcore.ErrorLevel
+	case logrus.FatalLevel:
+		return zapcore.FatalLevel
+	default:
+		return zapcore.PanicLevel
+	}
+}
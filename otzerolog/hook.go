@@ -0,0 +1,79 @@
+// Package otzerolog provides a zerolog writer exporting log events through the logger provider
+// of otel-tracing, so zerolog loggers share its resource and exporter configuration.
+package otzerolog
+
+import (
+	"github.com/rs/zerolog"
+	"github.com/sirupsen/logrus"
+	otellog "go.opentelemetry.io/otel/log"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// The event fields carrying the trace context, read by Writer.
+const (
+	traceIDFieldName    = "trace_id"
+	spanIDFieldName     = "span_id"
+	traceFlagsFieldName = "trace_flags"
+)
+
+// Hook is a zerolog.Hook adding the trace context of the event context set with Ctx to the
+// event fields, so Writer exports the event with it.
+type Hook struct{}
+
+// NewHook creates a Hook.
+func NewHook() *Hook {
+	return &Hook{}
+}
+
+// Run adds the trace context fields to the event.
+func (h *Hook) Run(e *zerolog.Event, level zerolog.Level, msg string) {
+	sc := oteltrace.SpanContextFromContext(e.GetCtx())
+	if !sc.IsValid() {
+		return
+	}
+	e.Str(traceIDFieldName, sc.TraceID().String()).
+		Str(spanIDFieldName, sc.SpanID().String()).
+		Str(traceFlagsFieldName, sc.TraceFlags().String())
+}
+
+// severity maps a zerolog level to the matching log severity.
+func severity(level zerolog.Level) otellog.Severity {
+	switch level {
+	case zerolog.TraceLevel:
+		return otellog.SeverityTrace
+	case zerolog.DebugLevel:
+		return otellog.SeverityDebug
+	case zerolog.InfoLevel:
+		return otellog.SeverityInfo
+	case zerolog.WarnLevel:
+		return otellog.SeverityWarn
+	case zerolog.ErrorLevel:
+		return otellog.SeverityError
+	case zerolog.FatalLevel:
+		return otellog.SeverityFatal
+	case zerolog.PanicLevel:
+		return otellog.SeverityFatal2
+	default:
+		return otellog.SeverityUndefined
+	}
+}
+
+// zerologLevel maps a logrus level to the matching zerolog level.
+func zerologLevel(level logrus.Level) zerolog.Level {
+	switch level {
+	case logrus.TraceLevel:
+		return zerolog.TraceLevel
+	case logrus.DebugLevel:
+		return zerolog.DebugLevel
+	case logrus.InfoLevel:
+		return zerolog.InfoLevel
+	case logrus.WarnLevel:
+		return zerolog.WarnLevel
+	case logrus.ErrorLevel:
+		return zerolog.ErrorLevel
+	case logrus.FatalLevel:
+		return zerolog.FatalLevel
+	default:
+		return zerolog.PanicLevel
+	}
+}
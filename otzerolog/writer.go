@@ -0,0 +1,139 @@
+package otzerolog
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	ot "github.com/faizal-asep-outlook/otel-tracing"
+	"github.com/rs/zerolog"
+	otellog "go.opentelemetry.io/otel/log"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// Writer is a zerolog.LevelWriter exporting the events through the logger provider, the event
+// fields becoming typed attributes. The trace context is read from the trace_id, span_id and
+// trace_flags fields added by Hook.
+type Writer struct {
+	logger otellog.Logger
+}
+
+// NewWriter creates a Writer exporting through the logger provider created by InitTracer,
+// enabled from the current base log level. It must be created after InitTracer.
+func NewWriter() *Writer {
+	return &Writer{
+		logger: ot.OtelLogger(),
+	}
+}
+
+// Write exports an event whose level is read from its level field.
+func (w *Writer) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel exports an event of the given level.
+func (w *Writer) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	fields := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(p))
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil {
+		return 0, fmt.Errorf("failed to decode zerolog event: %w", err)
+	}
+
+	if name, ok := fields[zerolog.LevelFieldName].(string); ok && level == zerolog.NoLevel {
+		if parsed, err := zerolog.ParseLevel(name); err == nil {
+			level = parsed
+		}
+	}
+	if level < zerologLevel(ot.LogLevel()) || level == zerolog.Disabled {
+		return len(p), nil
+	}
+
+	var record otellog.Record
+	record.SetTimestamp(eventTime(fields[zerolog.TimestampFieldName]))
+	if msg, ok := fields[zerolog.MessageFieldName].(string); ok {
+		record.SetBody(otellog.StringValue(msg))
+	}
+	record.SetSeverity(severity(level))
+	if level != zerolog.NoLevel {
+		record.SetSeverityText(level.String())
+	}
+
+	ctx := eventContext(fields)
+	for _, key := range []string{zerolog.LevelFieldName, zerolog.MessageFieldName, zerolog.TimestampFieldName,
+		traceIDFieldName, spanIDFieldName, traceFlagsFieldName} {
+		delete(fields, key)
+	}
+	for key, value := range fields {
+		record.AddAttributes(otellog.KeyValue{Key: key, Value: convertValue(value)})
+	}
+	w.logger.Emit(ctx, record)
+	return len(p), nil
+}
+
+// eventTime parses the timestamp field of an event, or returns the current time.
+func eventTime(v interface{}) time.Time {
+	if value, ok := v.(string); ok {
+		if t, err := time.Parse(zerolog.TimeFieldFormat, value); err == nil {
+			return t
+		}
+	}
+	return time.Now()
+}
+
+// eventContext returns a context holding the span context of the event trace fields.
+func eventContext(fields map[string]interface{}) context.Context {
+	ctx := context.Background()
+	traceID, err := oteltrace.TraceIDFromHex(fmt.Sprint(fields[traceIDFieldName]))
+	if err != nil {
+		return ctx
+	}
+	spanID, err := oteltrace.SpanIDFromHex(fmt.Sprint(fields[spanIDFieldName]))
+	if err != nil {
+		return ctx
+	}
+	var flags oteltrace.TraceFlags
+	if b, err := hex.DecodeString(fmt.Sprint(fields[traceFlagsFieldName])); err == nil && len(b) == 1 {
+		flags = oteltrace.TraceFlags(b[0])
+	}
+	return oteltrace.ContextWithSpanContext(ctx, oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: flags,
+	}))
+}
+
+// convertValue converts a decoded JSON value to a log value keeping its type.
+func convertValue(v interface{}) otellog.Value {
+	switch value := v.(type) {
+	case string:
+		return otellog.StringValue(value)
+	case bool:
+		return otellog.BoolValue(value)
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return otellog.Int64Value(i)
+		}
+		f, _ := value.Float64()
+		return otellog.Float64Value(f)
+	case map[string]interface{}:
+		kvs := make([]otellog.KeyValue, 0, len(value))
+		for k, item := range value {
+			kvs = append(kvs, otellog.KeyValue{Key: k, Value: convertValue(item)})
+		}
+		return otellog.MapValue(kvs...)
+	case []interface{}:
+		values := make([]otellog.Value, 0, len(value))
+		for _, item := range value {
+			values = append(values, convertValue(item))
+		}
+		return otellog.SliceValue(values...)
+	case nil:
+		return otellog.Value{}
+	default:
+		return otellog.StringValue(fmt.Sprint(value))
+	}
+}
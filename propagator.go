@@ -0,0 +1,56 @@
+package otelTracing
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/faizal-asep-outlook/otel-tracing/config"
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/contrib/propagators/ot"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// defaultPropagators is used when neither the config nor OTEL_PROPAGATORS choose the propagators.
+const defaultPropagators = "tracecontext,baggage"
+
+// newPropagator creates the composite text map propagator chosen by the config,
+// falling back to the OTEL_PROPAGATORS environment variable.
+func newPropagator(cfg config.Config) (propagation.TextMapPropagator, error) {
+	names := cfg.Propagators
+	if names == "" {
+		names = os.Getenv("OTEL_PROPAGATORS")
+	}
+	if names == "" {
+		names = defaultPropagators
+	}
+
+	var propagators []propagation.TextMapPropagator
+	for _, name := range strings.Split(names, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "":
+		case "none":
+			return propagation.NewCompositeTextMapPropagator(), nil
+		case "tracecontext":
+			propagators = append(propagators, propagation.TraceContext{})
+		case "baggage":
+			propagators = append(propagators, propagation.Baggage{})
+		case "b3":
+			propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3SingleHeader)))
+		case "b3multi":
+			propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)))
+		case "jaeger":
+			propagators = append(propagators, jaeger.Jaeger{})
+		case "xray":
+			propagators = append(propagators, xray.Propagator{})
+		case "ottrace", "ot":
+			propagators = append(propagators, ot.OT{})
+		default:
+			return nil, fmt.Errorf("unknown propagator: %s", name)
+		}
+	}
+
+	return propagation.NewCompositeTextMapPropagator(propagators...), nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/faizal-asep-outlook/otel-tracing/config"
@@ -12,6 +13,7 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
@@ -22,6 +24,7 @@ import (
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
@@ -58,16 +61,24 @@ func newLoggerProvider(ctx context.Context, cfg config.Config, res *resource.Res
 	}
 
 	processor := sdklog.NewBatchProcessor(exporter)
-	lp := sdklog.NewLoggerProvider(
+	opts := []sdklog.LoggerProviderOption{
 		sdklog.WithProcessor(processor),
 		sdklog.WithResource(res),
-	)
+	}
+	// unset limits keep the OTEL_LOGRECORD_*_LIMIT variables, or the SDK defaults
+	if cfg.AttributeCountLimit != 0 {
+		opts = append(opts, sdklog.WithAttributeCountLimit(cfg.AttributeCountLimit))
+	}
+	if cfg.AttributeValueLengthLimit != 0 {
+		opts = append(opts, sdklog.WithAttributeValueLengthLimit(cfg.AttributeValueLengthLimit))
+	}
+	lp := sdklog.NewLoggerProvider(opts...)
 
 	return lp, nil
 }
 
 // newTracerProvider creates a new tracer provider with the OTLP gRPC exporter.
-func newTracerProvider(ctx context.Context, cfg config.Config, res *resource.Resource) (*sdktrace.TracerProvider, error) {
+func newTracerProvider(ctx context.Context, cfg config.Config, res *resource.Resource, mp *sdkmetric.MeterProvider) (*sdktrace.TracerProvider, error) {
 	var (
 		exporter sdktrace.SpanExporter
 		err      error
@@ -95,16 +106,60 @@ func newTracerProvider(ctx context.Context, cfg config.Config, res *resource.Res
 		}
 	}
 
-	// Create Resource
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
+	processor := sdktrace.NewBatchSpanProcessor(exporter)
+	if cfg.TailSamplingEnabled {
+		processor, err = newTailSamplingProcessor(cfg, processor, mp.Meter(cfg.ServiceName))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithSpanProcessor(processor),
 		sdktrace.WithResource(res),
-	)
+		sdktrace.WithRawSpanLimits(newSpanLimits(cfg)),
+	}
+	if keys := parseBaggageKeys(cfg.BaggagePromoteKeys); len(keys) > 0 {
+		opts = append(opts, sdktrace.WithSpanProcessor(&baggageSpanProcessor{keys: keys}))
+	}
+	if cfg.SpanMetricsEnabled {
+		// span metrics see every span, before any tail sampling decision
+		spanMetrics, err := newSpanMetricsProcessor(mp.Meter(cfg.ServiceName))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithSpanProcessor(spanMetrics))
+	}
+
+	// Create Resource
+	tp := sdktrace.NewTracerProvider(opts...)
 	otel.SetTracerProvider(tp)
 
 	return tp, nil
 }
 
+// newSpanLimits creates the span limits from the config, a negative value means unlimited and
+// zero keeps the standard variables.
+func newSpanLimits(cfg config.Config) sdktrace.SpanLimits {
+	// unset limits keep the OTEL_SPAN_*_LIMIT variables, or the SDK defaults
+	limits := sdktrace.NewSpanLimits()
+	if cfg.AttributeCountLimit != 0 {
+		limits.AttributeCountLimit = cfg.AttributeCountLimit
+		limits.AttributePerEventCountLimit = cfg.AttributeCountLimit
+		limits.AttributePerLinkCountLimit = cfg.AttributeCountLimit
+	}
+	if cfg.AttributeValueLengthLimit != 0 {
+		limits.AttributeValueLengthLimit = cfg.AttributeValueLengthLimit
+	}
+	if cfg.SpanEventCountLimit != 0 {
+		limits.EventCountLimit = cfg.SpanEventCountLimit
+	}
+	if cfg.SpanLinkCountLimit != 0 {
+		limits.LinkCountLimit = cfg.SpanLinkCountLimit
+	}
+	return limits
+}
+
 // newMeterProvider creates a new meter provider with the OTLP gRPC exporter.
 func newMeterProvider(ctx context.Context, cfg config.Config, res *resource.Resource) (*sdkmetric.MeterProvider, error) {
 	var (
@@ -136,15 +191,38 @@ func newMeterProvider(ctx context.Context, cfg config.Config, res *resource.Reso
 		}
 	}
 
-	mp := sdkmetric.NewMeterProvider(
+	opts := []sdkmetric.Option{
 		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)),
 		sdkmetric.WithResource(res),
-	)
+	}
+	if cfg.ExemplarFilter != "" {
+		filter, err := newExemplarFilter(cfg.ExemplarFilter)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdkmetric.WithExemplarFilter(filter))
+	}
+
+	mp := sdkmetric.NewMeterProvider(opts...)
 	otel.SetMeterProvider(mp)
 
 	return mp, nil
 }
 
+// newExemplarFilter returns the exemplar filter matching the given name.
+func newExemplarFilter(name string) (exemplar.Filter, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "trace_based", "trace-based":
+		return exemplar.TraceBasedFilter, nil
+	case "always_on", "always":
+		return exemplar.AlwaysOnFilter, nil
+	case "always_off", "off":
+		return exemplar.AlwaysOffFilter, nil
+	default:
+		return nil, fmt.Errorf("unknown exemplar filter: %s", name)
+	}
+}
+
 // newResource creates a new OTEL resource with the service name and version.
 func newResource(ctx context.Context, cfg config.Config) (*resource.Resource, error) {
 	return resource.New(
@@ -163,9 +241,9 @@ func newResource(ctx context.Context, cfg config.Config) (*resource.Resource, er
 	)
 }
 
-func newHttpClient() *http.Client {
+func newHttpClient(propagator propagation.TextMapPropagator) *http.Client {
 	return &http.Client{
 		Timeout:   10 * time.Second,
-		Transport: otelhttp.NewTransport(http.DefaultTransport),
+		Transport: otelhttp.NewTransport(http.DefaultTransport, otelhttp.WithPropagators(propagator)),
 	}
 }
@@ -0,0 +1,217 @@
+package otelTracing
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	otellog "go.opentelemetry.io/otel/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// slogHandler is a slog.Handler exporting records through the logger provider and, when
+// enabled, writing them to the console.
+type slogHandler struct {
+	logger  otellog.Logger
+	console slog.Handler
+	attrs   []otellog.KeyValue
+	prefix  string
+}
+
+// NewSlogHandler creates a slog.Handler exporting through the logger provider with the trace
+// context of the record context, respecting the configured log level and console output.
+func NewSlogHandler() slog.Handler {
+	h := &slogHandler{
+		logger: OtelLogger(),
+	}
+	if tracingConfig.LogConsole != "" {
+		opts := &slog.HandlerOptions{Level: slogLevel(logrus.TraceLevel)}
+		if strings.ToLower(tracingConfig.LogConsoleFormat) == "json" {
+			h.console = slog.NewJSONHandler(loger.Out, opts)
+		} else {
+			h.console = slog.NewTextHandler(loger.Out, opts)
+		}
+	}
+	return h
+}
+
+// SlogLogger returns a *slog.Logger using NewSlogHandler.
+func SlogLogger() *slog.Logger {
+	return slog.New(NewSlogHandler())
+}
+
+// slogLevel maps a logrus level to the matching slog level.
+func slogLevel(level logrus.Level) slog.Level {
+	switch level {
+	case logrus.TraceLevel:
+		return slog.LevelDebug - 4
+	case logrus.DebugLevel:
+		return slog.LevelDebug
+	case logrus.InfoLevel:
+		return slog.LevelInfo
+	case logrus.WarnLevel:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
+// logrusLevel maps a slog level to the matching logrus level.
+func logrusLevel(level slog.Level) logrus.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return logrus.TraceLevel
+	case level < slog.LevelInfo:
+		return logrus.DebugLevel
+	case level < slog.LevelWarn:
+		return logrus.InfoLevel
+	case level < slog.LevelError:
+		return logrus.WarnLevel
+	default:
+		return logrus.ErrorLevel
+	}
+}
+
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	// the logger level is the most verbose one, the override of the caller is checked in Handle
+	return level >= slogLevel(loger.GetLevel())
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !logLevelEnabledPC(logrusLevel(r.Level), r.PC) {
+		return nil
+	}
+	if logsampler != nil && !logsampler.allow(ctx, logrusLevel(r.Level), templateKey(r.Message)) {
+		return nil
+	}
+
+	var record otellog.Record
+	record.SetTimestamp(r.Time)
+	record.SetBody(otellog.StringValue(r.Message))
+	record.SetSeverity(slogSeverity(r.Level))
+	record.SetSeverityText(r.Level.String())
+	record.AddAttributes(h.attrs...)
+
+	if tracingConfig.LogCaller && r.PC != 0 {
+		info := callerFromPC(r.PC)
+		record.AddAttributes(
+			otellog.String(string(semconv.CodeFunctionKey), info.function),
+			otellog.String(string(semconv.CodeNamespaceKey), info.namespace),
+			otellog.String(string(semconv.CodeFilepathKey), info.shortFile),
+			otellog.Int(string(semconv.CodeLineNumberKey), info.line),
+		)
+	}
+	for key, value := range contextLogFields(ctx) {
+		record.AddAttributes(slogAttrs("", slog.Any(key, value))...)
+	}
+	for key, value := range baggageFields(ctx) {
+		record.AddAttributes(otellog.String(key, fmt.Sprint(value)))
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		record.AddAttributes(slogAttrs(h.prefix, a)...)
+		return true
+	})
+	h.logger.Emit(ctx, record)
+
+	if h.console == nil {
+		return nil
+	}
+	if sc := oteltrace.SpanContextFromContext(ctx); sc.IsValid() {
+		r = r.Clone()
+		r.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+	return h.console.Handle(ctx, r)
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	dup := *h
+	dup.attrs = append([]otellog.KeyValue{}, h.attrs...)
+	for _, a := range attrs {
+		dup.attrs = append(dup.attrs, slogAttrs(h.prefix, a)...)
+	}
+	if h.console != nil {
+		dup.console = h.console.WithAttrs(attrs)
+	}
+	return &dup
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	dup := *h
+	dup.prefix = h.prefix + name + "."
+	if h.console != nil {
+		dup.console = h.console.WithGroup(name)
+	}
+	return &dup
+}
+
+// slogAttrs converts a slog attribute to log attributes, the keys of group members
+// are prefixed with the group name.
+func slogAttrs(prefix string, a slog.Attr) []otellog.KeyValue {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return nil
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix = prefix + a.Key + "."
+		}
+		var kvs []otellog.KeyValue
+		for _, member := range a.Value.Group() {
+			kvs = append(kvs, slogAttrs(prefix, member)...)
+		}
+		return kvs
+	}
+	return []otellog.KeyValue{{Key: prefix + a.Key, Value: slogValue(a.Value)}}
+}
+
+// slogValue converts a resolved slog value to a log value keeping its type.
+func slogValue(v slog.Value) otellog.Value {
+	switch v.Kind() {
+	case slog.KindString:
+		return otellog.StringValue(v.String())
+	case slog.KindInt64:
+		return otellog.Int64Value(v.Int64())
+	case slog.KindUint64:
+		return otellog.Int64Value(int64(v.Uint64()))
+	case slog.KindFloat64:
+		return otellog.Float64Value(v.Float64())
+	case slog.KindBool:
+		return otellog.BoolValue(v.Bool())
+	case slog.KindDuration:
+		return otellog.Int64Value(v.Duration().Nanoseconds())
+	case slog.KindTime:
+		return otellog.StringValue(v.Time().Format(time.RFC3339Nano))
+	}
+
+	switch value := v.Any().(type) {
+	case error:
+		return otellog.StringValue(value.Error())
+	case []byte:
+		return otellog.BytesValue(value)
+	default:
+		return otellog.StringValue(fmt.Sprint(value))
+	}
+}
+
+// slogSeverity maps a slog level to the log severity, slog levels are 4 apart like the
+// severities, info being 0 and 9. The custom levels out of range are clamped.
+func slogSeverity(level slog.Level) otellog.Severity {
+	switch {
+	case level < slog.Level(otellog.SeverityTrace1-9):
+		return otellog.SeverityTrace1
+	case level > slog.Level(otellog.SeverityFatal4-9):
+		return otellog.SeverityFatal4
+	default:
+		return otellog.Severity(level + 9)
+	}
+}
@@ -0,0 +1,100 @@
+package otelTracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// MetricSpanCounter is a metric that counts the total number of finished spans.
+var MetricSpanCounter = Metric{
+	Name:        "spans_total",
+	Unit:        "{count}",
+	Description: "Total number of finished spans",
+}
+
+// MetricSpanErrorCounter is a metric that counts the total number of finished spans with an error status.
+var MetricSpanErrorCounter = Metric{
+	Name:        "span_errors_total",
+	Unit:        "{count}",
+	Description: "Total number of finished spans with an error status",
+}
+
+// MetricSpanDurationMillis is a metric that measures the duration of finished spans, in milliseconds.
+var MetricSpanDurationMillis = Metric{
+	Name:        "span_duration",
+	Unit:        "ms",
+	Description: "Measures the duration of finished spans, in milliseconds.",
+}
+
+// spanMetricsProcessor derives request rate, error and duration metrics from finished spans.
+type spanMetricsProcessor struct {
+	calls    otelmetric.Int64Counter
+	errors   otelmetric.Int64Counter
+	duration otelmetric.Int64Histogram
+}
+
+// newSpanMetricsProcessor creates a span processor recording RED metrics with the given meter.
+func newSpanMetricsProcessor(m otelmetric.Meter) (*spanMetricsProcessor, error) {
+	calls, err := m.Int64Counter(
+		MetricSpanCounter.Name,
+		otelmetric.WithDescription(MetricSpanCounter.Description),
+		otelmetric.WithUnit(MetricSpanCounter.Unit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create span counter: %w", err)
+	}
+
+	errCounter, err := m.Int64Counter(
+		MetricSpanErrorCounter.Name,
+		otelmetric.WithDescription(MetricSpanErrorCounter.Description),
+		otelmetric.WithUnit(MetricSpanErrorCounter.Unit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create span error counter: %w", err)
+	}
+
+	duration, err := m.Int64Histogram(
+		MetricSpanDurationMillis.Name,
+		otelmetric.WithDescription(MetricSpanDurationMillis.Description),
+		otelmetric.WithUnit(MetricSpanDurationMillis.Unit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create span histogram: %w", err)
+	}
+
+	return &spanMetricsProcessor{
+		calls:    calls,
+		errors:   errCounter,
+		duration: duration,
+	}, nil
+}
+
+func (p *spanMetricsProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {}
+
+func (p *spanMetricsProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	ctx := context.Background()
+	attrs := otelmetric.WithAttributes(
+		attribute.String("span.name", s.Name()),
+		attribute.String("span.kind", s.SpanKind().String()),
+		attribute.String("status.code", s.Status().Code.String()),
+	)
+
+	p.calls.Add(ctx, 1, attrs)
+	if s.Status().Code == codes.Error {
+		p.errors.Add(ctx, 1, attrs)
+	}
+	p.duration.Record(ctx, s.EndTime().Sub(s.StartTime()).Milliseconds(), attrs)
+}
+
+func (p *spanMetricsProcessor) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (p *spanMetricsProcessor) ForceFlush(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,384 @@
+package otelTracing
+
+import (
+	"container/list"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/faizal-asep-outlook/otel-tracing/config"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// MetricTailSamplingDecisions is a metric that counts the decisions taken by the tail sampling processor.
+var MetricTailSamplingDecisions = Metric{
+	Name:        "tail_sampling_decisions_total",
+	Unit:        "{count}",
+	Description: "Total number of traces kept or dropped by the tail sampling processor",
+}
+
+const (
+	tailDecisionSampled = "sampled"
+	tailDecisionDropped = "dropped"
+
+	tailReasonError     = "error"
+	tailReasonLatency   = "latency"
+	tailReasonAttribute = "attribute"
+	tailReasonBaseRate  = "base_rate"
+	tailReasonNone      = "none"
+
+	tailTriggerCompleted   = "completed"
+	tailTriggerTimeout     = "timeout"
+	tailTriggerSpanLimit   = "span_limit"
+	tailTriggerMemoryLimit = "memory_limit"
+	tailTriggerFlush       = "flush"
+	tailTriggerShutdown    = "shutdown"
+)
+
+// tailSamplingRule matches a span attribute by key and, when value is not empty, by value.
+type tailSamplingRule struct {
+	key   attribute.Key
+	value string
+}
+
+// pendingTrace holds the spans of a trace that is waiting for a sampling decision.
+type pendingTrace struct {
+	id        oteltrace.TraceID
+	spans     []sdktrace.ReadOnlySpan
+	arrival   time.Time
+	deadline  time.Time
+	completed bool
+	elem      *list.Element
+}
+
+// tailDecision is the sampling decision of a trace taken out of the buffer.
+type tailDecision struct {
+	trace   *pendingTrace
+	keep    bool
+	reason  string
+	trigger string
+}
+
+// tailSamplingProcessor buffers ended spans per trace and forwards the whole trace
+// to the next processor only when it is decided to be kept.
+type tailSamplingProcessor struct {
+	next sdktrace.SpanProcessor
+
+	decisionWait     time.Duration
+	completionWait   time.Duration
+	latencyThreshold time.Duration
+	baseRateBound    uint64
+	rules            []tailSamplingRule
+	maxTraces        int
+	maxSpans         int
+
+	mu       sync.Mutex
+	pending  map[oteltrace.TraceID]*pendingTrace
+	order    *list.List
+	decided  map[oteltrace.TraceID]bool
+	history  []oteltrace.TraceID
+	position int
+	stopped  bool
+
+	decisions otelmetric.Int64Counter
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// newTailSamplingProcessor creates a tail sampling span processor in front of next.
+func newTailSamplingProcessor(cfg config.Config, next sdktrace.SpanProcessor, m otelmetric.Meter) (*tailSamplingProcessor, error) {
+	decisions, err := m.Int64Counter(
+		MetricTailSamplingDecisions.Name,
+		otelmetric.WithDescription(MetricTailSamplingDecisions.Description),
+		otelmetric.WithUnit(MetricTailSamplingDecisions.Unit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tail sampling counter: %w", err)
+	}
+
+	rate := cfg.TailSamplingBaseRate
+	if rate < 0 {
+		rate = 0
+	}
+	var bound uint64
+	if rate >= 1 {
+		bound = 1 << 63
+	} else {
+		bound = uint64(rate * (1 << 63))
+	}
+
+	maxTraces := cfg.TailSamplingMaxTraces
+	if maxTraces <= 0 {
+		maxTraces = 10000
+	}
+	maxSpans := cfg.TailSamplingMaxSpansPerTrace
+	if maxSpans <= 0 {
+		maxSpans = 1000
+	}
+	wait := time.Duration(cfg.TailSamplingDecisionWaitMs) * time.Millisecond
+	if wait <= 0 {
+		wait = 10 * time.Second
+	}
+
+	p := &tailSamplingProcessor{
+		next:             next,
+		decisionWait:     wait,
+		completionWait:   time.Duration(cfg.TailSamplingCompletionWaitMs) * time.Millisecond,
+		latencyThreshold: time.Duration(cfg.TailSamplingLatencyThresholdMs) * time.Millisecond,
+		baseRateBound:    bound,
+		rules:            parseTailSamplingRules(cfg.TailSamplingAttributes),
+		maxTraces:        maxTraces,
+		maxSpans:         maxSpans,
+		pending:          make(map[oteltrace.TraceID]*pendingTrace),
+		order:            list.New(),
+		decided:          make(map[oteltrace.TraceID]bool),
+		history:          make([]oteltrace.TraceID, maxTraces),
+		decisions:        decisions,
+		stop:             make(chan struct{}),
+		done:             make(chan struct{}),
+	}
+	go p.run()
+
+	return p, nil
+}
+
+// parseTailSamplingRules parses rules in the form "key=value,key".
+func parseTailSamplingRules(raw string) []tailSamplingRule {
+	var rules []tailSamplingRule
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(part, "=")
+		rules = append(rules, tailSamplingRule{
+			key:   attribute.Key(strings.TrimSpace(key)),
+			value: strings.TrimSpace(value),
+		})
+	}
+	return rules
+}
+
+func (p *tailSamplingProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p *tailSamplingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	id := s.SpanContext().TraceID()
+
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return
+	}
+	// spans ending after their trace was decided follow the same decision
+	if keep, ok := p.decided[id]; ok {
+		p.mu.Unlock()
+		if keep {
+			p.next.OnEnd(s)
+		}
+		return
+	}
+
+	var ready []tailDecision
+	t, ok := p.pending[id]
+	if !ok {
+		if len(p.pending) >= p.maxTraces {
+			if oldest := p.order.Front(); oldest != nil {
+				ready = append(ready, p.decideLocked(oldest.Value.(*pendingTrace), tailTriggerMemoryLimit))
+			}
+		}
+		now := time.Now()
+		t = &pendingTrace{id: id, arrival: now, deadline: now.Add(p.decisionWait)}
+		t.elem = p.order.PushBack(t)
+		p.pending[id] = t
+	}
+	t.spans = append(t.spans, s)
+
+	// the local root ending marks the completion of the trace in this process, the spans ending
+	// later, such as the ones of goroutines, are still buffered for the completion wait
+	if parent := s.Parent(); !parent.IsValid() || parent.IsRemote() {
+		t.completed = true
+		t.deadline = time.Now().Add(p.completionWait)
+	}
+	if len(t.spans) >= p.maxSpans {
+		ready = append(ready, p.decideLocked(t, tailTriggerSpanLimit))
+	} else if t.completed && p.completionWait <= 0 {
+		ready = append(ready, p.decideLocked(t, tailTriggerCompleted))
+	}
+	p.mu.Unlock()
+
+	for _, d := range ready {
+		p.forward(d)
+	}
+}
+
+// remove takes a pending trace out of the buffer. p.mu must be held.
+func (p *tailSamplingProcessor) remove(t *pendingTrace) {
+	p.order.Remove(t.elem)
+	delete(p.pending, t.id)
+}
+
+// decideLocked takes a trace out of the buffer, evaluates it and remembers the decision in
+// the same critical section, so the spans of the trace ending meanwhile follow the decision
+// instead of opening a new pending trace. p.mu must be held.
+func (p *tailSamplingProcessor) decideLocked(t *pendingTrace, trigger string) tailDecision {
+	p.remove(t)
+	keep, reason := p.evaluate(t)
+
+	if old := p.history[p.position]; old.IsValid() {
+		delete(p.decided, old)
+	}
+	p.history[p.position] = t.id
+	p.position = (p.position + 1) % len(p.history)
+	p.decided[t.id] = keep
+
+	return tailDecision{trace: t, keep: keep, reason: reason, trigger: trigger}
+}
+
+// forward forwards the spans of a kept trace and counts the decision.
+func (p *tailSamplingProcessor) forward(d tailDecision) {
+	decision := tailDecisionDropped
+	if d.keep {
+		decision = tailDecisionSampled
+		for _, s := range d.trace.spans {
+			p.next.OnEnd(s)
+		}
+	}
+	p.decisions.Add(context.Background(), 1, otelmetric.WithAttributes(
+		attribute.String("decision", decision),
+		attribute.String("reason", d.reason),
+		attribute.String("trigger", d.trigger),
+	))
+}
+
+// evaluate returns whether a trace should be kept and why.
+func (p *tailSamplingProcessor) evaluate(t *pendingTrace) (bool, string) {
+	var start, end time.Time
+	matched := false
+	for _, s := range t.spans {
+		if s.Status().Code == codes.Error {
+			return true, tailReasonError
+		}
+		if start.IsZero() || s.StartTime().Before(start) {
+			start = s.StartTime()
+		}
+		if s.EndTime().After(end) {
+			end = s.EndTime()
+		}
+		if !matched && p.matches(s) {
+			matched = true
+		}
+	}
+	if p.latencyThreshold > 0 && end.Sub(start) >= p.latencyThreshold {
+		return true, tailReasonLatency
+	}
+	if matched {
+		return true, tailReasonAttribute
+	}
+	if binary.BigEndian.Uint64(t.id[8:16])>>1 < p.baseRateBound {
+		return true, tailReasonBaseRate
+	}
+	return false, tailReasonNone
+}
+
+// matches reports whether one of the span attributes satisfies a rule.
+func (p *tailSamplingProcessor) matches(s sdktrace.ReadOnlySpan) bool {
+	for _, rule := range p.rules {
+		for _, kv := range s.Attributes() {
+			if kv.Key == rule.key && (rule.value == "" || kv.Value.Emit() == rule.value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// run decides the traces that exceeded the decision wait or, once completed, the completion wait.
+func (p *tailSamplingProcessor) run() {
+	defer close(p.done)
+
+	interval := p.decisionWait / 2
+	if p.completionWait > 0 && p.completionWait/2 < interval {
+		interval = p.completionWait / 2
+	}
+	if interval < 100*time.Millisecond {
+		interval = 100 * time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case now := <-ticker.C:
+			var expired []tailDecision
+			p.mu.Lock()
+			// the buffer is ordered by arrival, not by deadline since completion shortens it
+			for e := p.order.Front(); e != nil; {
+				t := e.Value.(*pendingTrace)
+				e = e.Next()
+				if now.Before(t.deadline) {
+					continue
+				}
+				trigger := tailTriggerTimeout
+				if t.completed {
+					trigger = tailTriggerCompleted
+				}
+				expired = append(expired, p.decideLocked(t, trigger))
+			}
+			p.mu.Unlock()
+
+			for _, d := range expired {
+				p.forward(d)
+			}
+		}
+	}
+}
+
+// flush decides every pending trace.
+func (p *tailSamplingProcessor) flush(trigger string) {
+	p.mu.Lock()
+	decisions := make([]tailDecision, 0, len(p.pending))
+	for e := p.order.Front(); e != nil; e = p.order.Front() {
+		decisions = append(decisions, p.decideLocked(e.Value.(*pendingTrace), trigger))
+	}
+	p.mu.Unlock()
+
+	for _, d := range decisions {
+		p.forward(d)
+	}
+}
+
+func (p *tailSamplingProcessor) ForceFlush(ctx context.Context) error {
+	p.flush(tailTriggerFlush)
+	return p.next.ForceFlush(ctx)
+}
+
+func (p *tailSamplingProcessor) Shutdown(ctx context.Context) error {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	p.flush(tailTriggerShutdown)
+	p.mu.Lock()
+	p.stopped = true
+	p.mu.Unlock()
+
+	return p.next.Shutdown(ctx)
+}
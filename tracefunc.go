@@ -0,0 +1,57 @@
+package otelTracing
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// Trace runs fn inside a new span with the given name. An error returned by fn is recorded
+// on the span and sets its status, a panic is recorded as an exception event before it is re-panicked.
+func Trace(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	_, err := TraceValue(ctx, name, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// TraceValue runs fn inside a new span with the given name and returns its value, see Trace.
+func TraceValue[T any](ctx context.Context, name string, fn func(ctx context.Context) (T, error)) (value T, err error) {
+	ctx, span := Tracer.Start(ctx, name)
+	defer func() {
+		if r := recover(); r != nil {
+			recordPanic(span, r)
+			span.End()
+			panic(r)
+		}
+		recordSpanError(span, err)
+		span.End()
+	}()
+
+	return fn(ctx)
+}
+
+// recordSpanError records err on the span and marks the span as errored.
+func recordSpanError(span oteltrace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// recordPanic records a recovered panic value as an exception event with the stack trace.
+func recordPanic(span oteltrace.Span, r interface{}) {
+	message := fmt.Sprint(r)
+	span.AddEvent(semconv.ExceptionEventName, oteltrace.WithAttributes(
+		semconv.ExceptionType(fmt.Sprintf("%T", r)),
+		semconv.ExceptionMessage(message),
+		semconv.ExceptionStacktrace(string(debug.Stack())),
+		semconv.ExceptionEscaped(true),
+	))
+	span.SetStatus(codes.Error, "panic: "+message)
+}
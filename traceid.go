@@ -0,0 +1,69 @@
+package otelTracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/propagation"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// TraceIDFromContext returns the hex trace ID of the span in ctx, or an empty string.
+func TraceIDFromContext(ctx context.Context) string {
+	sc := oteltrace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// SpanIDFromContext returns the hex span ID of the span in ctx, or an empty string.
+func SpanIDFromContext(ctx context.Context) string {
+	sc := oteltrace.SpanContextFromContext(ctx)
+	if !sc.HasSpanID() {
+		return ""
+	}
+	return sc.SpanID().String()
+}
+
+// GinTraceID returns the trace ID of the request span started by MiddlewareGinTrace.
+func GinTraceID(c *gin.Context) string {
+	return TraceIDFromContext(requestContext(c))
+}
+
+// GinSpanID returns the span ID of the request span started by MiddlewareGinTrace.
+func GinSpanID(c *gin.Context) string {
+	return SpanIDFromContext(requestContext(c))
+}
+
+// EncodeSpanContext serializes a span context into a compact W3C traceparent string,
+// such as a string stored with a background job. It returns an empty string for an invalid span context.
+func EncodeSpanContext(sc oteltrace.SpanContext) string {
+	if !sc.IsValid() {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID(), sc.SpanID(), sc.TraceFlags())
+}
+
+// DecodeSpanContext parses a span context serialized by EncodeSpanContext. The returned
+// span context is marked as remote.
+func DecodeSpanContext(s string) (oteltrace.SpanContext, error) {
+	ctx := propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier{"traceparent": s})
+	sc := oteltrace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return oteltrace.SpanContext{}, fmt.Errorf("invalid span context: %q", s)
+	}
+	return sc, nil
+}
+
+// StartLinkedRoot starts a new root span with the given name linked to the remote span context,
+// such as the span of the request that enqueued a background job. The span must be ended by calling End.
+func StartLinkedRoot(ctx context.Context, name string, remote oteltrace.SpanContext, opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+	opts = append([]oteltrace.SpanStartOption{oteltrace.WithNewRoot()}, opts...)
+	if remote.IsValid() {
+		opts = append(opts, oteltrace.WithLinks(oteltrace.Link{SpanContext: remote}))
+	}
+	//nolint: spancheck
+	return Tracer.Start(ctx, name, opts...)
+}
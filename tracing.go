@@ -4,16 +4,16 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
-	"runtime"
 	"strings"
 
 	"github.com/faizal-asep-outlook/otel-tracing/config"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
-	"go.opentelemetry.io/contrib/bridges/otellogrus"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	otelmetric "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
@@ -30,6 +30,7 @@ var meterprovider *sdkmetric.MeterProvider
 var loger *logrus.Logger
 var httpclient *http.Client
 var meter otelmetric.Meter
+var tracingConfig config.Config
 
 type noopWriter struct{}
 
@@ -39,11 +40,33 @@ func (noopWriter) Write(p []byte) (n int, err error) {
 
 type OtelTracing interface {
 	TraceStart(ctx context.Context, name string) (context.Context, oteltrace.Span)
+	TraceStartWithOptions(ctx context.Context, name string, opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span)
+	TraceStartClient(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, oteltrace.Span)
+	TraceStartProducer(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, oteltrace.Span)
+	TraceStartConsumer(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, oteltrace.Span)
+	TraceStartInternal(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, oteltrace.Span)
+	TraceStartAuto(ctx context.Context, opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span)
+	Trace(ctx context.Context, name string, fn func(ctx context.Context) error) error
+	Go(ctx context.Context, name string, fn func(ctx context.Context) error)
+	NewGroup(ctx context.Context, name string) (*Group, context.Context)
 	ShutDown(ctx context.Context) error
 
-	MiddlewareGinTrace() gin.HandlerFunc
+	MiddlewareGinTrace(opts ...GinTraceOption) gin.HandlerFunc
 	MiddlewareLogger() gin.HandlerFunc
 
+	Inject(ctx context.Context, carrier propagation.TextMapCarrier)
+	Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context
+
+	StartLinkedRoot(ctx context.Context, name string, remote oteltrace.SpanContext, opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span)
+	TraceIDFromContext(ctx context.Context) string
+	SpanIDFromContext(ctx context.Context) string
+
+	SetBaggage(ctx context.Context, key, value string) (context.Context, error)
+	GetBaggage(ctx context.Context, key string) string
+
+	WithLogFields(ctx context.Context, fields logrus.Fields) context.Context
+	LoggerFromContext(ctx context.Context) *logrus.Entry
+
 	LogTrace(ctx context.Context, args ...interface{})
 	LogDebug(ctx context.Context, args ...interface{})
 	LogPrint(ctx context.Context, args ...interface{})
@@ -53,6 +76,29 @@ type OtelTracing interface {
 	LogFatal(ctx context.Context, args ...interface{})
 	LogPanic(ctx context.Context, args ...interface{})
 
+	LogTracef(ctx context.Context, format string, args ...interface{})
+	LogDebugf(ctx context.Context, format string, args ...interface{})
+	LogPrintf(ctx context.Context, format string, args ...interface{})
+	LogInfof(ctx context.Context, format string, args ...interface{})
+	LogWarnf(ctx context.Context, format string, args ...interface{})
+	LogErrorf(ctx context.Context, format string, args ...interface{})
+	LogFatalf(ctx context.Context, format string, args ...interface{})
+	LogPanicf(ctx context.Context, format string, args ...interface{})
+	LogTraceKV(ctx context.Context, msg string, keyvals ...interface{})
+	LogDebugKV(ctx context.Context, msg string, keyvals ...interface{})
+	LogPrintKV(ctx context.Context, msg string, keyvals ...interface{})
+	LogInfoKV(ctx context.Context, msg string, keyvals ...interface{})
+	LogWarnKV(ctx context.Context, msg string, keyvals ...interface{})
+	LogErrorKV(ctx context.Context, msg string, keyvals ...interface{})
+	LogFatalKV(ctx context.Context, msg string, keyvals ...interface{})
+	LogPanicKV(ctx context.Context, msg string, keyvals ...interface{})
+
+	Slog() *slog.Logger
+
+	SetLogLevel(level logrus.Level)
+	SetLogLevelOverride(pkg string, level logrus.Level)
+	RemoveLogLevelOverride(pkg string)
+
 	HttpDo(ctx context.Context, req *http.Request) (*http.Response, error)
 	HttpGet(ctx context.Context, url string) (*http.Response, error)
 	HttpPost(ctx context.Context, url, contentType string, body io.Reader) (*http.Response, error)
@@ -76,32 +122,55 @@ func InitTracer() (OtelTracing, error) {
 		return nil, fmt.Errorf("failed to create logger: %w", err)
 	}
 
-	// Create an *otellogrus.Hook and use it in your application.
-	hook := otellogrus.NewHook(config.ServiceName, otellogrus.WithLoggerProvider(lp))
-	// Set the newly created hook as a global logrus hook
-	log := logrus.New()
-	log.AddHook(hook)
-	log.SetOutput(&noopWriter{})
+	log, err := newLogger(config, lp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	mp, err := newMeterProvider(ctx, config, rp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create meter: %w", err)
+	}
 
-	tp, err := newTracerProvider(ctx, config, rp)
+	tp, err := newTracerProvider(ctx, config, rp, mp)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tracer: %w", err)
 	}
 
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
-	otel.Tracer("gin-server")
+	spanEventLevel, err := logrus.ParseLevel(config.LogSpanEventsLevel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse span events log level: %w", err)
+	}
 
-	mp, err := newMeterProvider(ctx, config, rp)
+	sampler, err := newLogSampler(config, mp.Meter(config.ServiceName))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create meter: %w", err)
+		return nil, fmt.Errorf("failed to create log sampler: %w", err)
+	}
+
+	levels, err := newLogLevels(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create log levels: %w", err)
+	}
+
+	propagator, err := newPropagator(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create propagator: %w", err)
 	}
+	otel.SetTextMapPropagator(propagator)
+	otel.Tracer("gin-server")
 	//
 
-	httpclient = newHttpClient()
+	httpclient = newHttpClient(propagator)
 	logprovider = lp
 	tracerprovider = tp
 	meterprovider = mp
 	loger = log
+	baseloger = newBaseLogger(log)
+	setLogLevels(levels)
+	logsampler = sampler
+	logSpanEventLevel = spanEventLevel
+	tracingConfig = config
+	baggageKeys = parseBaggageKeys(config.BaggagePromoteKeys)
 	Tracer = tp.Tracer(config.ServiceName)
 	meter = mp.Meter(config.ServiceName)
 	return &otelTracing{}, nil
@@ -113,68 +182,94 @@ func TraceStart(ctx context.Context, name string) (context.Context, oteltrace.Sp
 	return Tracer.Start(ctx, name)
 }
 
+// TraceStartWithOptions starts a new span with the given name and options such as
+// oteltrace.WithAttributes, oteltrace.WithSpanKind, oteltrace.WithLinks or oteltrace.WithTimestamp.
+// The span must be ended by calling End.
+func TraceStartWithOptions(ctx context.Context, name string, opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+	//nolint: spancheck
+	return Tracer.Start(ctx, name, opts...)
+}
+
+// TraceStartClient starts a new client span for an outgoing request. The span must be ended by calling End.
+func TraceStartClient(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, oteltrace.Span) {
+	//nolint: spancheck
+	return traceStartKind(ctx, name, oteltrace.SpanKindClient, attrs)
+}
+
+// TraceStartProducer starts a new producer span for an enqueued message. The span must be ended by calling End.
+func TraceStartProducer(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, oteltrace.Span) {
+	//nolint: spancheck
+	return traceStartKind(ctx, name, oteltrace.SpanKindProducer, attrs)
+}
+
+// TraceStartConsumer starts a new consumer span for a received message. The span must be ended by calling End.
+func TraceStartConsumer(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, oteltrace.Span) {
+	//nolint: spancheck
+	return traceStartKind(ctx, name, oteltrace.SpanKindConsumer, attrs)
+}
+
+// TraceStartInternal starts a new internal span. The span must be ended by calling End.
+func TraceStartInternal(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, oteltrace.Span) {
+	//nolint: spancheck
+	return traceStartKind(ctx, name, oteltrace.SpanKindInternal, attrs)
+}
+
+func traceStartKind(ctx context.Context, name string, kind oteltrace.SpanKind, attrs []attribute.KeyValue) (context.Context, oteltrace.Span) {
+	//nolint: spancheck
+	return Tracer.Start(ctx, name, oteltrace.WithSpanKind(kind), oteltrace.WithAttributes(attrs...))
+}
+
 func LogTrace(ctx context.Context, args ...interface{}) {
-	if _, file, len, ok := runtime.Caller(1); ok {
-		loger.WithContext(ctx).WithField("file", fmt.Sprintf("%s(%d)", file, len)).Trace(args...)
-	} else {
-		loger.WithContext(ctx).Trace(args...)
-	}
+	logArgs(ctx, 1, logrus.TraceLevel, args)
 }
 
 func LogDebug(ctx context.Context, args ...interface{}) {
-	if _, file, len, ok := runtime.Caller(1); ok {
-		loger.WithContext(ctx).WithField("file", fmt.Sprintf("%s(%d)", file, len)).Debug(args...)
-	} else {
-		loger.WithContext(ctx).Debug(args...)
-	}
+	logArgs(ctx, 1, logrus.DebugLevel, args)
 }
 
 func LogPrint(ctx context.Context, args ...interface{}) {
-	if _, file, len, ok := runtime.Caller(1); ok {
-		loger.WithContext(ctx).WithField("file", fmt.Sprintf("%s(%d)", file, len)).Print(args...)
-	} else {
-		loger.WithContext(ctx).Print(args...)
-	}
+	logArgs(ctx, 1, logrus.InfoLevel, args)
 }
 
 func LogInfo(ctx context.Context, args ...interface{}) {
-	if _, file, len, ok := runtime.Caller(1); ok {
-		loger.WithContext(ctx).WithField("file", fmt.Sprintf("%s(%d)", file, len)).Info(args...)
-	} else {
-		loger.WithContext(ctx).Info(args...)
-	}
+	logArgs(ctx, 1, logrus.InfoLevel, args)
 }
 
 func LogWarn(ctx context.Context, args ...interface{}) {
-	if _, file, len, ok := runtime.Caller(1); ok {
-		loger.WithContext(ctx).WithField("file", fmt.Sprintf("%s(%d)", file, len)).Warn(args...)
-	} else {
-		loger.WithContext(ctx).Warn(args...)
-	}
+	logArgs(ctx, 1, logrus.WarnLevel, args)
 }
 
 func LogError(ctx context.Context, args ...interface{}) {
-	if _, file, len, ok := runtime.Caller(1); ok {
-		loger.WithContext(ctx).WithField("file", fmt.Sprintf("%s(%d)", file, len)).Error(args...)
-	} else {
-		loger.WithContext(ctx).Error(args...)
-	}
+	logArgs(ctx, 1, logrus.ErrorLevel, args)
 }
 
 func LogFatal(ctx context.Context, args ...interface{}) {
-	if _, file, len, ok := runtime.Caller(1); ok {
-		loger.WithContext(ctx).WithField("file", fmt.Sprintf("%s(%d)", file, len)).Fatal(args...)
-	} else {
-		loger.WithContext(ctx).Fatal(args...)
-	}
+	logArgs(ctx, 1, logrus.FatalLevel, args)
 }
 
 func LogPanic(ctx context.Context, args ...interface{}) {
-	if _, file, len, ok := runtime.Caller(1); ok {
-		loger.WithContext(ctx).WithField("file", fmt.Sprintf("%s(%d)", file, len)).Panic(args...)
-	} else {
-		loger.WithContext(ctx).Panic(args...)
+	logArgs(ctx, 1, logrus.PanicLevel, args)
+}
+
+// logArgs logs args at the given level, skip is the number of frames above logArgs to report as caller.
+func logArgs(ctx context.Context, skip int, level logrus.Level, args []interface{}) {
+	logMessage(ctx, skip+1, level, "", args, nil)
+}
+
+// logEntry creates the log entry for ctx with the location of the call site at pc, the log
+// fields of ctx and the promoted baggage members.
+func logEntry(ctx context.Context, pc uintptr) *logrus.Entry {
+	entry := loger.WithContext(ctx)
+	if tracingConfig.LogCaller && pc != 0 {
+		entry = entry.WithFields(callerFromPC(pc).fields)
+	}
+	if fields := contextLogFields(ctx); len(fields) > 0 {
+		entry = entry.WithFields(fields)
+	}
+	if fields := baggageFields(ctx); len(fields) > 0 {
+		entry = entry.WithFields(fields)
 	}
+	return entry
 }
 
 func HttpDo(ctx context.Context, req *http.Request) (*http.Response, error) {